@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// PostgreSQL Academic Period Model (admin-managed semester)
+type AcademicPeriod struct {
+	Code         string     `json:"code"`         // e.g. 2025-GANJIL
+	Name         string     `json:"name"`         // e.g. Semester Ganjil 2025/2026
+	AcademicYear string     `json:"academicYear"` // e.g. 2025/2026
+	Semester     string     `json:"semester"`     // GANJIL, GENAP or PENDEK
+	StartDate    time.Time  `json:"startDate"`
+	EndDate      time.Time  `json:"endDate"` // inclusive
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+}
+
+// Contains reports whether a moment falls on one of the period's days
+func (p *AcademicPeriod) Contains(t time.Time) bool {
+	return !t.Before(p.StartDate) && t.Before(p.EndExclusive())
+}
+
+// EndExclusive is the first moment after the period
+func (p *AcademicPeriod) EndExclusive() time.Time {
+	return p.EndDate.AddDate(0, 0, 1)
+}
+
+type SaveAcademicPeriodRequest struct {
+	Name      string `json:"name"`
+	StartDate string `json:"startDate"` // YYYY-MM-DD
+	EndDate   string `json:"endDate"`   // YYYY-MM-DD, inclusive
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -9,14 +11,21 @@ import (
 // MongoDB Achievement Model
 type Achievement struct {
 	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	StudentID       string             `bson:"studentId" json:"studentId"`
-	AchievementType string             `bson:"achievementType" json:"achievementType"` // academic, competition, organization, publication, certification, other
+	StudentID       string             `bson:"studentId" json:"studentId"` // creator; the team leader for team achievements
+	TeamMembers     []TeamMember       `bson:"teamMembers,omitempty" json:"teamMembers,omitempty"`
+	AchievementType string             `bson:"achievementType" json:"achievementType"` // name of a managed AchievementTypeDefinition
 	Title           string             `bson:"title" json:"title"`
 	Description     string             `bson:"description" json:"description"`
 	Details         AchievementDetails `bson:"details" json:"details"`
 	Attachments     []Attachment       `bson:"attachments" json:"attachments"`
 	Tags            []string           `bson:"tags" json:"tags"`
-	Points          int                `bson:"points" json:"points"`
+	Points          int                `bson:"points" json:"points"` // computed by the points engine
+	PointsRuleID    string             `bson:"pointsRuleId,omitempty" json:"pointsRuleId,omitempty"`
+	PointsVersion   int                `bson:"pointsRuleVersion" json:"pointsRuleVersion"`
+	NormalizedTitle string             `bson:"normalizedTitle,omitempty" json:"-"` // lookup key for duplicate detection
+	EventAt         *time.Time         `bson:"eventAt" json:"-"`                   // Details.EventTime, stored for period filters; null without one
+	Duplicates      []DuplicateMatch   `bson:"possibleDuplicates,omitempty" json:"possibleDuplicates,omitempty"`
+	ExpiredAt       *time.Time         `bson:"expiredAt,omitempty" json:"expiredAt,omitempty"` // set by the expiry job once details.validUntil has passed
 	IsDeleted       bool               `bson:"isDeleted" json:"-"`
 	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
@@ -36,6 +45,7 @@ type AchievementDetails struct {
 	Authors          []string `bson:"authors,omitempty" json:"authors,omitempty"`
 	Publisher        string   `bson:"publisher,omitempty" json:"publisher,omitempty"`
 	ISSN             string   `bson:"issn,omitempty" json:"issn,omitempty"`
+	DOI              string   `bson:"doi,omitempty" json:"doi,omitempty"`
 	
 	// Organization fields
 	OrganizationName string        `bson:"organizationName,omitempty" json:"organizationName,omitempty"`
@@ -55,24 +65,115 @@ type AchievementDetails struct {
 	CustomFields map[string]interface{} `bson:"customFields,omitempty" json:"customFields,omitempty"`
 }
 
+// TeamMember is one student credited with a team achievement; each member has their own reference
+type TeamMember struct {
+	StudentID string `bson:"studentId" json:"studentId"`
+	Role      string `bson:"role" json:"role"`     // e.g. leader, developer, designer
+	Points    int    `bson:"points" json:"points"` // the member's share under the team points policy
+}
+
+// ReportDate is when the achievement happened, used by reports, period filters and point caps
+// alike: the details' EventTime, else CreatedAt
+func (a *Achievement) ReportDate() time.Time {
+	if t := a.Details.EventTime(); t != nil {
+		return *t
+	}
+	return a.CreatedAt
+}
+
+// EventTime is the date the details give: EventDate (YYYY-MM-DD or RFC3339), else the start of
+// Period; nil when there is neither
+func (d *AchievementDetails) EventTime() *time.Time {
+	if d.EventDate != "" {
+		for _, layout := range []string{"2006-01-02", time.RFC3339} {
+			if t, err := time.Parse(layout, d.EventDate); err == nil {
+				return &t
+			}
+		}
+	}
+	if d.Period != nil && !d.Period.Start.IsZero() {
+		start := d.Period.Start
+		return &start
+	}
+	return nil
+}
+
+// IsTeam reports whether the achievement is shared by several students
+func (a *Achievement) IsTeam() bool {
+	return len(a.TeamMembers) > 0
+}
+
+// PointsFor returns the points a student earns from the achievement
+func (a *Achievement) PointsFor(studentID string) int {
+	for _, member := range a.TeamMembers {
+		if member.StudentID == studentID {
+			return member.Points
+		}
+	}
+	return a.Points
+}
+
+// DuplicateMatch points at another achievement that looks like the same accomplishment
+type DuplicateMatch struct {
+	AchievementID string   `bson:"achievementId" json:"achievementId"`
+	StudentID     string   `bson:"studentId" json:"studentId"`
+	Title         string   `bson:"title" json:"title"`
+	Reasons       []string `bson:"reasons" json:"reasons"` // title, competition, certificationNumber, issn
+}
+
 type PeriodDetail struct {
 	Start time.Time `bson:"start" json:"start"`
 	End   time.Time `bson:"end" json:"end"`
 }
 
 type Attachment struct {
+	ID         string    `bson:"id,omitempty" json:"id,omitempty"`
 	FileName   string    `bson:"fileName" json:"fileName"`
 	FileURL    string    `bson:"fileUrl" json:"fileUrl"`
 	FileType   string    `bson:"fileType" json:"fileType"`
+	Size       int64     `bson:"size,omitempty" json:"size,omitempty"`
+	SHA256     string    `bson:"sha256,omitempty" json:"sha256,omitempty"`
+	StorageKey string    `bson:"storageKey,omitempty" json:"-"` // empty for legacy link-only attachments
 	UploadedAt time.Time `bson:"uploadedAt" json:"uploadedAt"`
 }
 
+// MongoDB Achievement Revision Model (snapshot of achievement content)
+type AchievementRevision struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AchievementID string             `bson:"achievementId" json:"achievementId"`
+	Revision      int                `bson:"revision" json:"revision"`
+	Title         string             `bson:"title" json:"title"`
+	Description   string             `bson:"description" json:"description"`
+	Details       AchievementDetails `bson:"details" json:"details"`
+	Attachments   []Attachment       `bson:"attachments" json:"attachments"`
+	Tags          []string           `bson:"tags" json:"tags"`
+	Points        int                `bson:"points" json:"points"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type AchievementRevisionDiff struct {
+	AchievementID      string        `json:"achievementId"`
+	FromRevision       int           `json:"fromRevision"`
+	ToRevision         int           `json:"toRevision"`
+	Changes            []FieldChange `json:"changes"`
+	TagsAdded          []string      `json:"tagsAdded"`
+	TagsRemoved        []string      `json:"tagsRemoved"`
+	AttachmentsAdded   []Attachment  `json:"attachmentsAdded"`
+	AttachmentsRemoved []Attachment  `json:"attachmentsRemoved"`
+}
+
 // PostgreSQL Achievement Reference Model
 type AchievementReference struct {
 	ID                 string     `json:"id"`
 	StudentID          string     `json:"studentId"`
 	MongoAchievementID string     `json:"mongoAchievementId"`
-	Status             string     `json:"status"` // draft, submitted, verified, rejected
+	Status             string     `json:"status"` // draft, submitted, <stage>_approved, verified, rejected, deleted
 	SubmittedAt        *time.Time `json:"submittedAt"`
 	VerifiedAt         *time.Time `json:"verifiedAt"`
 	VerifiedBy         *string    `json:"verifiedBy"`
@@ -81,14 +182,165 @@ type AchievementReference struct {
 	UpdatedAt          time.Time  `json:"updatedAt"`
 }
 
+// approvalStatusSuffix marks the intermediate status reached once an approval stage signs off
+const approvalStatusSuffix = "_approved"
+
+// ApprovalStatus is the intermediate status reached once an approval stage signs off
+func ApprovalStatus(stageName string) string {
+	return stageName + approvalStatusSuffix
+}
+
+// IsApprovalStatus reports whether status is an intermediate approval-chain status such as advisor_approved
+func IsApprovalStatus(status string) bool {
+	return strings.HasSuffix(status, approvalStatusSuffix)
+}
+
+// IsPendingReview reports whether an achievement is waiting on some approver
+func IsPendingReview(status string) bool {
+	return status == "submitted" || IsApprovalStatus(status)
+}
+
+// PostgreSQL Achievement Status History Model
+type AchievementStatusHistory struct {
+	ID                     string    `json:"id"`
+	AchievementReferenceID string    `json:"achievementReferenceId"`
+	FromStatus             string    `json:"fromStatus"`
+	ToStatus               string    `json:"toStatus"`
+	ActorID                string    `json:"actorId"`
+	ActorRole              string    `json:"actorRole"`
+	Note                   *string   `json:"note,omitempty"`
+	CreatedAt              time.Time `json:"createdAt"`
+}
+
+// AchievementTransition is a status change of one reference; it applies only while the reference
+// is still in History.FromStatus, and the history row is written in the same transaction
+type AchievementTransition struct {
+	History       AchievementStatusHistory
+	SubmittedAt   *time.Time
+	VerifiedBy    *string // set on verification, which also stamps verified_at
+	RejectionNote *string
+	Comment       *AchievementComment // a change request saved together with the status change
+}
+
+// PostgreSQL Achievement Comment Model
+type AchievementComment struct {
+	ID                     string               `json:"id"`
+	AchievementReferenceID string               `json:"achievementReferenceId"`
+	ParentID               *string              `json:"parentId,omitempty"`
+	AuthorID               string               `json:"authorId"`
+	AuthorRole             string               `json:"authorRole"`
+	Kind                   string               `json:"kind"` // comment, change_request
+	Body                   string               `json:"body"`
+	Resolved               bool                 `json:"resolved"`
+	CreatedAt              time.Time            `json:"createdAt"`
+	Replies                []AchievementComment `json:"replies,omitempty"`
+}
+
+type CommentCount struct {
+	Total      int `json:"total"`
+	Unresolved int `json:"unresolved"`
+}
+
+// PostgreSQL Achievement Type Model (admin-managed)
+type AchievementTypeDefinition struct {
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	RequiredFields       []string               `json:"requiredFields"`               // AchievementDetails JSON field names
+	CustomFieldsSchema   map[string]interface{} `json:"customFieldsSchema,omitempty"` // JSON schema for details.customFields
+	DefaultPoints        int                    `json:"defaultPoints"`                // used when no points rule matches
+	EvidenceRequirements []EvidenceRequirement  `json:"evidenceRequirements"`         // checked at submit time
+	IsActive             bool                   `json:"isActive"`
+	CreatedAt            *time.Time             `json:"createdAt,omitempty"`
+	UpdatedAt            *time.Time             `json:"updatedAt,omitempty"`
+}
+
+// UpdateAchievementTypeRequest changes only the fields present in the body; deactivation has its own endpoint
+type UpdateAchievementTypeRequest struct {
+	Description          *string                `json:"description"`
+	RequiredFields       *[]string              `json:"requiredFields"`
+	CustomFieldsSchema   OptionalSchema         `json:"customFieldsSchema"` // null removes the schema
+	DefaultPoints        *int                   `json:"defaultPoints"`
+	EvidenceRequirements *[]EvidenceRequirement `json:"evidenceRequirements"`
+	IsActive             *bool                  `json:"isActive"`
+}
+
+// OptionalSchema tells an omitted JSON schema apart from an explicit null
+type OptionalSchema struct {
+	Set    bool
+	Schema map[string]interface{}
+}
+
+func (o *OptionalSchema) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	o.Schema = nil
+	if string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, &o.Schema)
+}
+
+// EvidenceRequirement is met by an attachment of one of FileTypes or by any of DetailFields being filled in
+type EvidenceRequirement struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	FileTypes    []string `json:"fileTypes,omitempty"`    // sniffed content types, e.g. application/pdf
+	DetailFields []string `json:"detailFields,omitempty"` // AchievementDetails JSON field names, e.g. doi
+}
+
+// PostgreSQL Points Rule Models
+type PointsRule struct {
+	ID               string `json:"id"`
+	AchievementType  string `json:"achievementType"`
+	CompetitionLevel string `json:"competitionLevel,omitempty"` // empty matches any level
+	MedalType        string `json:"medalType,omitempty"`
+	PublicationType  string `json:"publicationType,omitempty"`
+	MinRank          int    `json:"minRank,omitempty"` // 0 means no rank bound
+	MaxRank          int    `json:"maxRank,omitempty"`
+	Points           int    `json:"points"`
+}
+
+// PointsCap limits the points one student can earn from a type within an academic period
+type PointsCap struct {
+	AchievementType string `json:"achievementType"`
+	Period          string `json:"period"` // semester or academic_year
+	MaxPoints       int    `json:"maxPoints"`
+}
+
+type PointsRuleSet struct {
+	Version    int          `json:"version"` // 0 is the built-in default table
+	Rules      []PointsRule `json:"rules"`
+	Caps       []PointsCap  `json:"caps"`
+	TeamPolicy string       `json:"teamPolicy"` // duplicate or split
+	CreatedBy  string       `json:"createdBy,omitempty"`
+	CreatedAt  *time.Time   `json:"createdAt,omitempty"`
+}
+
+// PostgreSQL Approval Chain Models
+type ApprovalStage struct {
+	Order       int    `json:"order"`
+	Name        string `json:"name"`       // advisor, department, faculty
+	Permission  string `json:"permission"` // permission required to approve this stage
+	AdvisorOnly bool   `json:"advisorOnly"`
+}
+
+type ApprovalChain struct {
+	AchievementType  string          `json:"achievementType"`
+	CompetitionLevel string          `json:"competitionLevel"`
+	Stages           []ApprovalStage `json:"stages"`
+}
+
 // Combined Achievement Response (MongoDB + PostgreSQL)
 type AchievementResponse struct {
 	Achievement
-	Status        string     `json:"status"`
-	SubmittedAt   *time.Time `json:"submittedAt,omitempty"`
-	VerifiedAt    *time.Time `json:"verifiedAt,omitempty"`
-	VerifiedBy    *string    `json:"verifiedBy,omitempty"`
-	RejectionNote *string    `json:"rejectionNote,omitempty"`
+	ReferenceID   string                     `json:"referenceId"` // team members each have their own reference to the shared document
+	Status        string                     `json:"status"`
+	SubmittedAt   *time.Time                 `json:"submittedAt,omitempty"`
+	VerifiedAt    *time.Time                 `json:"verifiedAt,omitempty"`
+	VerifiedBy    *string                    `json:"verifiedBy,omitempty"`
+	RejectionNote *string                    `json:"rejectionNote,omitempty"`
+	CurrentStage  string                     `json:"currentStage,omitempty"`
+	Comments      CommentCount               `json:"comments"`
+	StatusHistory []AchievementStatusHistory `json:"statusHistory,omitempty"`
 }
 
 // Request DTOs
@@ -98,7 +350,8 @@ type CreateAchievementRequest struct {
 	Description     string             `json:"description"`
 	Details         AchievementDetails `json:"details"`
 	Tags            []string           `json:"tags"`
-	Points          int                `json:"points"`
+	TeamMembers     []TeamMember       `json:"teamMembers"` // other members; the creator is added as leader
+	Points          int                `json:"points"`      // ignored, computed by the points engine
 }
 
 type UpdateAchievementRequest struct {
@@ -106,7 +359,13 @@ type UpdateAchievementRequest struct {
 	Description string             `json:"description"`
 	Details     AchievementDetails `json:"details"`
 	Tags        []string           `json:"tags"`
-	Points      int                `json:"points"`
+	Points      int                `json:"points"` // ignored, computed by the points engine
+}
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type VerifyAchievementRequest struct {
@@ -114,23 +373,58 @@ type VerifyAchievementRequest struct {
 	Note   *string `json:"note"`                       // required for reject
 }
 
-type UploadAttachmentRequest struct {
-	FileName string `json:"fileName" validate:"required"`
-	FileURL  string `json:"fileUrl" validate:"required"`
-	FileType string `json:"fileType" validate:"required"`
+type CreateCommentRequest struct {
+	Body     string  `json:"body" validate:"required"`
+	Kind     string  `json:"kind"` // comment (default) or change_request
+	ParentID *string `json:"parentId"`
+}
+
+type SavePointsRulesRequest struct {
+	Rules      []PointsRule `json:"rules" validate:"required"`
+	Caps       []PointsCap  `json:"caps"`
+	TeamPolicy string       `json:"teamPolicy"` // duplicate (default) or split
+}
+
+type BatchVerifyItem struct {
+	AchievementID string  `json:"achievementId"`
+	Action        string  `json:"action"` // verify or reject
+	Note          *string `json:"note"`
+}
+
+type BatchVerifyRequest struct {
+	Items []BatchVerifyItem `json:"items" validate:"required"`
+}
+
+type BatchVerifyResult struct {
+	AchievementID string `json:"achievementId"`
+	Action        string `json:"action"`
+	Result        string `json:"result"` // succeeded, skipped, forbidden, not_found, failed
+	Status        string `json:"status,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type BatchVerifyResponse struct {
+	Results   []BatchVerifyResult `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Skipped   int                 `json:"skipped"`
+	Forbidden int                 `json:"forbidden"`
+	NotFound  int                 `json:"notFound"`
+	Failed    int                 `json:"failed"`
 }
 
 // Filter and statistics models for Phase 4
 type AchievementFilter struct {
-	Status          *string `json:"status"`
-	AchievementType *string `json:"achievementType"`
-	StudentID       *string `json:"studentId"`
-	DateFrom        *string `json:"dateFrom"`
-	DateTo          *string `json:"dateTo"`
-	SortBy          *string `json:"sortBy"`   // createdAt, updatedAt, title
-	SortOrder       *string `json:"sortOrder"` // asc, desc
-	Page            int     `json:"page"`
-	Limit           int     `json:"limit"`
+	Status          *string         `json:"status"`
+	AchievementType *string         `json:"achievementType"`
+	StudentID       *string         `json:"studentId"`
+	DateFrom        *string         `json:"dateFrom"`
+	DateTo          *string         `json:"dateTo"`
+	Period          *string         `json:"period"` // academic period code, e.g. 2025-GANJIL
+	AcademicPeriod  *AcademicPeriod `json:"-"`      // resolved from Period
+	SortBy          *string         `json:"sortBy"`    // createdAt, updatedAt, title
+	SortOrder       *string         `json:"sortOrder"` // asc, desc
+	Page            int             `json:"page"`
+	Limit           int             `json:"limit"`
 }
 
 type AchievementStatistics struct {
@@ -141,20 +435,95 @@ type AchievementStatistics struct {
 	TopStudents            []StudentAchievementCount `json:"topStudents"`
 	RecentVerified         int                       `json:"recentVerified"`
 	PendingVerification    int                       `json:"pendingVerification"`
-	TotalPoints            int                       `json:"totalPoints"`
+	TotalPoints            int                       `json:"totalPoints"` // verified points after each student's period caps
+	PointsBreakdown
+	VerificationSLADays    int                       `json:"verificationSlaDays"`
+	ExpiredWeight          int                       `json:"expiredWeight"` // percent of expired points still counted
+	Period                 string                    `json:"period,omitempty"` // academic period the statistics cover
+	OverdueSubmissions     int                       `json:"overdueSubmissions"`
+	AvgTimeToVerify        []VerifierTurnaround      `json:"avgTimeToVerify"`
+}
+
+// VerifierTurnaround is the average time a verifier took from submission to verification
+type VerifierTurnaround struct {
+	VerifierID   string  `json:"verifierId"`
+	VerifierName string  `json:"verifierName"`
+	Verified     int     `json:"verified"`
+	AvgHours     float64 `json:"avgHours"`
+}
+
+type OverdueAchievement struct {
+	AchievementResponse
+	DueAt       time.Time `json:"dueAt"`
+	DaysOverdue int       `json:"daysOverdue"`
+}
+
+// ExpiringAchievement is an achievement whose validity ends soon and needs renewing
+type ExpiringAchievement struct {
+	AchievementResponse
+	ValidUntil time.Time `json:"validUntil"`
+	DaysLeft   int       `json:"daysLeft"`
 }
 
 type StudentAchievementCount struct {
 	StudentID   string `json:"studentId"`
 	StudentName string `json:"studentName"`
 	NIM         string `json:"nim"`
-	Count       int    `json:"count"`
-	TotalPoints int    `json:"totalPoints"`
+	Count       int    `json:"count"`       // verified achievements
+	TotalPoints int    `json:"totalPoints"` // verified points after period caps, as in the student's report
+	PointsBreakdown
+	Verified    []Achievement `json:"-"` // verified achievements credited to the student, Points set to their share
+}
+
+// PointsBreakdown splits points by review outcome; drafts count towards none of them
+type PointsBreakdown struct {
+	VerifiedPoints int `json:"verifiedPoints"`
+	PendingPoints  int `json:"pendingPoints"`
+	RejectedPoints int `json:"rejectedPoints"`
+	ExpiredPoints  int `json:"expiredPoints"` // raw points of verified achievements that have since expired
 }
 
-type MonthlyStatistics struct {
-	Month string `json:"month"`
-	Count int    `json:"count"`
+// Add counts points towards the bucket of the given achievement status
+func (b *PointsBreakdown) Add(status string, points int) {
+	switch {
+	case status == "verified":
+		b.VerifiedPoints += points
+	case status == "rejected":
+		b.RejectedPoints += points
+	case IsPendingReview(status):
+		b.PendingPoints += points
+	}
+}
+
+// AddExpired counts the points of an expired achievement; until the expiry policy is applied
+// they are still part of VerifiedPoints
+func (b *PointsBreakdown) AddExpired(status string, points int) {
+	if status == "verified" {
+		b.ExpiredPoints += points
+	}
+}
+
+// DailyStatistics counts references to achievements dated on a day (YYYY-MM-DD), per trend group
+// key and reference status
+type DailyStatistics struct {
+	Day    string `json:"day"`
+	Key    string `json:"key"`
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// TrendPoint is one time bucket of a trend chart
+type TrendPoint struct {
+	Period string         `json:"period"` // 2025-03, 2025-GANJIL or 2025/2026 depending on granularity
+	Total  int            `json:"total"`
+	Counts map[string]int `json:"counts"`
+}
+
+type AchievementTrends struct {
+	Granularity string       `json:"granularity"` // month, semester or year
+	GroupBy     string       `json:"groupBy"`     // type, status or level
+	Keys        []string     `json:"keys"`        // every group key that occurs, one chart series each
+	Points      []TrendPoint `json:"points"`
 }
 
 type StudentReportResponse struct {
@@ -163,8 +532,23 @@ type StudentReportResponse struct {
 	NIM                string                    `json:"nim"`
 	ProgramStudy       string                    `json:"programStudy"`
 	TotalAchievements  int                       `json:"totalAchievements"`
-	TotalPoints        int                       `json:"totalPoints"`
+	TotalPoints        int                       `json:"totalPoints"`    // verified points after period caps
+	RawTotalPoints     int                       `json:"rawTotalPoints"` // verified points before period caps
+	PointsBreakdown
+	ExpiredWeight      int                       `json:"expiredWeight"` // percent of expired points still counted
+	Period             string                    `json:"period,omitempty"` // academic period the report covers
+	Clipped            []ClippedAchievement      `json:"clippedAchievements"`
 	ByType             map[string]int            `json:"byType"`
 	ByStatus           map[string]int            `json:"byStatus"`
 	Achievements       []AchievementResponse     `json:"achievements"`
 }
+
+// ClippedAchievement is a verified achievement whose points were reduced by a period cap
+type ClippedAchievement struct {
+	AchievementID   string `json:"achievementId"`
+	Title           string `json:"title"`
+	AchievementType string `json:"achievementType"`
+	Period          string `json:"period"`
+	RawPoints       int    `json:"rawPoints"`
+	CountedPoints   int    `json:"countedPoints"`
+}
@@ -9,4 +9,22 @@ type Lecturers struct {
 	FullName    string    `json:"full_name"`
 	Department 	string    `json:"department"`
 	CreatedAt 	time.Time `json:"created_at"`
-}
\ No newline at end of file
+}
+
+// LecturerDelegation hands an advisor's verification duties to another lecturer for a date range
+type LecturerDelegation struct {
+	ID           string    `json:"id"`
+	DelegatorID  string    `json:"delegator_id"`
+	DelegateID   string    `json:"delegate_id"`
+	DelegateName string    `json:"delegate_name"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CreateDelegationRequest struct {
+	DelegateID string `json:"delegate_id"`
+	StartDate  string `json:"start_date"` // YYYY-MM-DD
+	EndDate    string `json:"end_date"`   // YYYY-MM-DD, inclusive
+}
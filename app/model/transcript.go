@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// PostgreSQL Transcript Document Model (an issued SKPI achievement transcript)
+type TranscriptDocument struct {
+	ID                   string    `json:"id"`
+	Number               string    `json:"documentNumber"` // unique, e.g. SKPI/2026/000042
+	StudentID            string    `json:"studentId"`
+	Period               string    `json:"period,omitempty"` // academic period code, empty for all time
+	VerifiedAchievements int       `json:"verifiedAchievements"`
+	TotalPoints          int       `json:"totalPoints"`
+	IssuedBy             string    `json:"issuedBy"`
+	IssuedAt             time.Time `json:"issuedAt"`
+}
+
+// Transcript is everything the PDF renderer needs: the student report it is built from,
+// the issued document and the names of the lecturers who verified the achievements
+type Transcript struct {
+	Document      TranscriptDocument
+	Report        *StudentReportResponse
+	VerifierNames map[string]string // user ID to full name
+}
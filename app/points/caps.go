@@ -0,0 +1,119 @@
+package points
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"student-report/app/model"
+)
+
+const (
+	CapPeriodSemester     = "semester"
+	CapPeriodAcademicYear = "academic_year"
+)
+
+// CapResult is how many of an achievement's points survive the period caps
+type CapResult struct {
+	AchievementID string
+	Period        string
+	RawPoints     int
+	Points        int
+	Clipped       bool
+}
+
+// ApplyCaps enforces the rule set's period caps over one student's achievements.
+// Achievements are counted in chronological order, so later ones get clipped first.
+// Periods are the configured academic periods used to bucket each achievement date.
+func (e *Engine) ApplyCaps(achievements []model.Achievement, periods []model.AcademicPeriod) []CapResult {
+	caps := make(map[string]model.PointsCap)
+	for _, c := range e.ruleSet.Caps {
+		caps[c.AchievementType] = c
+	}
+
+	sorted := append([]model.Achievement{}, achievements...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ReportDate().Before(sorted[j].ReportDate())
+	})
+
+	used := make(map[string]int) // type|period -> points counted so far
+	results := make([]CapResult, 0, len(sorted))
+	for i := range sorted {
+		a := &sorted[i]
+		result := CapResult{AchievementID: a.ID.Hex(), RawPoints: a.Points, Points: a.Points}
+
+		if c, ok := caps[a.AchievementType]; ok {
+			result.Period = ResolvePeriodKey(a.ReportDate(), c.Period, periods)
+			key := a.AchievementType + "|" + result.Period
+			remaining := c.MaxPoints - used[key]
+			if remaining < 0 {
+				remaining = 0
+			}
+			if result.Points > remaining {
+				result.Points = remaining
+				result.Clipped = true
+			}
+			used[key] += result.Points
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ValidateCaps checks period caps before they are saved with a rule version
+func ValidateCaps(caps []model.PointsCap) error {
+	seen := make(map[string]bool)
+	for i, c := range caps {
+		if c.AchievementType == "" {
+			return fmt.Errorf("cap %d: achievement type is required", i+1)
+		}
+		if c.Period != CapPeriodSemester && c.Period != CapPeriodAcademicYear {
+			return fmt.Errorf("cap %d: period must be %s or %s", i+1, CapPeriodSemester, CapPeriodAcademicYear)
+		}
+		if c.MaxPoints < 0 {
+			return errors.New("cap max points cannot be negative")
+		}
+		if seen[c.AchievementType] {
+			return fmt.Errorf("duplicate cap for achievement type %s", c.AchievementType)
+		}
+		seen[c.AchievementType] = true
+	}
+	return nil
+}
+
+// ResolvePeriodKey names the configured academic period (its code, or its academic year) a date
+// falls in. Dates outside every configured period fall back to PeriodKey.
+func ResolvePeriodKey(t time.Time, period string, periods []model.AcademicPeriod) string {
+	for i := range periods {
+		if !periods[i].Contains(t) {
+			continue
+		}
+		if period == CapPeriodAcademicYear {
+			return periods[i].AcademicYear
+		}
+		return periods[i].Code
+	}
+	return PeriodKey(t, period)
+}
+
+// PeriodKey names the default academic semester (2025-GANJIL for Aug 2025 - Jan 2026, 2025-GENAP
+// for Feb - Jul 2026) or academic year (2025/2026) a date falls in
+func PeriodKey(t time.Time, period string) string {
+	year := t.Year()
+	semester := "GANJIL"
+	switch {
+	case t.Month() == time.January:
+		year--
+	case t.Month() < time.August:
+		year--
+		semester = "GENAP"
+	}
+
+	if period == CapPeriodAcademicYear {
+		return fmt.Sprintf("%d/%d", year, year+1)
+	}
+	return fmt.Sprintf("%d-%s", year, semester)
+}
@@ -0,0 +1,44 @@
+package points
+
+import "student-report/app/model"
+
+// DefaultRuleSet is used until an admin saves the first rule table (version 0)
+func DefaultRuleSet() model.PointsRuleSet {
+	rules := []model.PointsRule{}
+
+	competition := []struct {
+		level                               string
+		first, second, third, participation int
+	}{
+		{"international", 100, 80, 60, 40},
+		{"national", 70, 55, 40, 25},
+		{"regional", 40, 30, 20, 15},
+		{"local", 20, 15, 10, 5},
+	}
+	for _, c := range competition {
+		rules = append(rules,
+			model.PointsRule{ID: "default-competition-" + c.level + "-1", AchievementType: "competition", CompetitionLevel: c.level, MinRank: 1, MaxRank: 1, Points: c.first},
+			model.PointsRule{ID: "default-competition-" + c.level + "-2", AchievementType: "competition", CompetitionLevel: c.level, MinRank: 2, MaxRank: 2, Points: c.second},
+			model.PointsRule{ID: "default-competition-" + c.level + "-3", AchievementType: "competition", CompetitionLevel: c.level, MinRank: 3, MaxRank: 3, Points: c.third},
+			model.PointsRule{ID: "default-competition-" + c.level, AchievementType: "competition", CompetitionLevel: c.level, Points: c.participation},
+		)
+	}
+
+	rules = append(rules,
+		model.PointsRule{ID: "default-competition", AchievementType: "competition", Points: 5},
+		model.PointsRule{ID: "default-publication-journal", AchievementType: "publication", PublicationType: "journal", Points: 50},
+		model.PointsRule{ID: "default-publication-book", AchievementType: "publication", PublicationType: "book", Points: 40},
+		model.PointsRule{ID: "default-publication-conference", AchievementType: "publication", PublicationType: "conference", Points: 30},
+		model.PointsRule{ID: "default-publication", AchievementType: "publication", Points: 20},
+		model.PointsRule{ID: "default-academic", AchievementType: "academic", Points: 30},
+		model.PointsRule{ID: "default-certification", AchievementType: "certification", Points: 25},
+		model.PointsRule{ID: "default-organization", AchievementType: "organization", Points: 20},
+		model.PointsRule{ID: "default-other", AchievementType: "other", Points: 10},
+	)
+
+	caps := []model.PointsCap{
+		{AchievementType: "organization", Period: CapPeriodSemester, MaxPoints: 40},
+	}
+
+	return model.PointsRuleSet{Version: 0, Rules: rules, Caps: caps}
+}
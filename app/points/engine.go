@@ -0,0 +1,113 @@
+// Package points computes achievement points from an admin-editable rule table
+// instead of trusting the value a student submits.
+package points
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"student-report/app/model"
+)
+
+// Result is the outcome of scoring one achievement
+type Result struct {
+	Points  int
+	RuleID  string
+	Version int
+}
+
+// Engine scores achievements against a single version of the rule table
+type Engine struct {
+	ruleSet model.PointsRuleSet
+}
+
+func NewEngine(ruleSet model.PointsRuleSet) *Engine {
+	return &Engine{ruleSet: ruleSet}
+}
+
+// Calculate picks the most specific matching rule; achievements without a match score 0
+func (e *Engine) Calculate(achievement *model.Achievement) Result {
+	result := Result{Version: e.ruleSet.Version}
+
+	best := -1
+	for _, rule := range e.ruleSet.Rules {
+		if !matches(rule, achievement) {
+			continue
+		}
+		// Earlier rules win ties so admins control precedence by ordering
+		if score := specificity(rule); score > best {
+			best = score
+			result.Points = rule.Points
+			result.RuleID = rule.ID
+		}
+	}
+
+	return result
+}
+
+// Validate checks a rule table before it is saved as a new version
+func Validate(rules []model.PointsRule) error {
+	if len(rules) == 0 {
+		return errors.New("points rule table needs at least one rule")
+	}
+
+	for i, rule := range rules {
+		if rule.AchievementType == "" {
+			return fmt.Errorf("rule %d: achievement type is required", i+1)
+		}
+		if rule.Points < 0 {
+			return fmt.Errorf("rule %d: points cannot be negative", i+1)
+		}
+		if rule.MinRank < 0 || rule.MaxRank < 0 {
+			return fmt.Errorf("rule %d: rank bounds cannot be negative", i+1)
+		}
+		if rule.MaxRank > 0 && rule.MinRank > rule.MaxRank {
+			return fmt.Errorf("rule %d: minRank is greater than maxRank", i+1)
+		}
+	}
+	return nil
+}
+
+func matches(rule model.PointsRule, achievement *model.Achievement) bool {
+	details := achievement.Details
+
+	if !strings.EqualFold(rule.AchievementType, achievement.AchievementType) {
+		return false
+	}
+	if rule.CompetitionLevel != "" && !strings.EqualFold(rule.CompetitionLevel, details.CompetitionLevel) {
+		return false
+	}
+	if rule.MedalType != "" && !strings.EqualFold(rule.MedalType, details.MedalType) {
+		return false
+	}
+	if rule.PublicationType != "" && !strings.EqualFold(rule.PublicationType, details.PublicationType) {
+		return false
+	}
+	if rule.MinRank > 0 || rule.MaxRank > 0 {
+		if details.Rank <= 0 || details.Rank < rule.MinRank {
+			return false
+		}
+		if rule.MaxRank > 0 && details.Rank > rule.MaxRank {
+			return false
+		}
+	}
+	return true
+}
+
+func specificity(rule model.PointsRule) int {
+	score := 0
+	if rule.CompetitionLevel != "" {
+		score++
+	}
+	if rule.MedalType != "" {
+		score++
+	}
+	if rule.PublicationType != "" {
+		score++
+	}
+	if rule.MinRank > 0 || rule.MaxRank > 0 {
+		score++
+	}
+	return score
+}
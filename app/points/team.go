@@ -0,0 +1,43 @@
+package points
+
+import "fmt"
+
+// Team point policies: every member earns the full points, or the points are shared
+const (
+	TeamPolicyDuplicate = "duplicate"
+	TeamPolicySplit     = "split"
+)
+
+// TeamPolicy returns the rule set's team policy, duplicating points when none is set
+func (e *Engine) TeamPolicy() string {
+	if e.ruleSet.TeamPolicy == "" {
+		return TeamPolicyDuplicate
+	}
+	return e.ruleSet.TeamPolicy
+}
+
+// TeamShares divides an achievement's points among its members under the team policy.
+// When splitting, the remainder goes to the first members so the shares add up to the total.
+func (e *Engine) TeamShares(total, members int) []int {
+	shares := make([]int, members)
+	for i := range shares {
+		if e.TeamPolicy() == TeamPolicySplit {
+			shares[i] = total / members
+			if i < total%members {
+				shares[i]++
+			}
+		} else {
+			shares[i] = total
+		}
+	}
+	return shares
+}
+
+// ValidateTeamPolicy checks a team policy before it is saved; empty means the default
+func ValidateTeamPolicy(policy string) error {
+	switch policy {
+	case "", TeamPolicyDuplicate, TeamPolicySplit:
+		return nil
+	}
+	return fmt.Errorf("team policy must be %s or %s", TeamPolicyDuplicate, TeamPolicySplit)
+}
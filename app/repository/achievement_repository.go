@@ -3,11 +3,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
+	"unicode"
 
 	"student-report/app/model"
 
+	"github.com/lib/pq"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -27,24 +32,58 @@ type IAchievementRepository interface {
 	CreateAchievementMongo(ctx context.Context, achievement *model.Achievement) (string, error)
 	GetAchievementMongo(ctx context.Context, mongoID string) (*model.Achievement, error)
 	UpdateAchievementMongo(ctx context.Context, mongoID string, update *model.Achievement) error
+	UpdateAchievementPointsMongo(ctx context.Context, mongoID string, points int, ruleID string, version int, teamMembers []model.TeamMember) error
 	SoftDeleteAchievementMongo(ctx context.Context, mongoID string) error
+	DeleteAchievementMongo(ctx context.Context, mongoID string) error
 	AddAttachmentMongo(ctx context.Context, mongoID string, attachment model.Attachment) error
+	FindDuplicateCandidatesMongo(ctx context.Context, achievement *model.Achievement) ([]model.Achievement, error)
+	SetPossibleDuplicatesMongo(ctx context.Context, mongoID string, matches []model.DuplicateMatch) error
+	MarkExpiredAchievementsMongo(ctx context.Context, now time.Time) (int64, error)
+	GetExpiringAchievementsMongo(ctx context.Context, studentIDs []string, from, until time.Time) ([]model.Achievement, error)
+	ReplaceAttachmentMongo(ctx context.Context, mongoID string, attachment model.Attachment) error
+	RemoveAttachmentMongo(ctx context.Context, mongoID, attachmentID string) error
+	AssignAttachmentIDsMongo(ctx context.Context, mongoID string) error
 	GetAchievementsByStudentIDs(ctx context.Context, studentIDs []string) ([]model.Achievement, error)
 	GetAchievementsWithFilter(ctx context.Context, filter model.AchievementFilter) ([]model.Achievement, int64, error)
-	GetAchievementStatistics(ctx context.Context, studentIDs []string) (*model.AchievementStatistics, error)
-	GetTopStudents(ctx context.Context, limit int) ([]model.StudentAchievementCount, error)
+	GetAchievementStatistics(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) (*model.AchievementStatistics, error)
+	GetStudentPoints(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) ([]model.StudentAchievementCount, error)
+	GetDailyStatistics(ctx context.Context, studentIDs []string, groupBy string) ([]model.DailyStatistics, error)
 	GetStudentInfo(studentID string) (string, string)
+	StudentExists(studentID string) (bool, error)
+	GetUserNames(userIDs []string) (map[string]string, error)
+	CreateAchievementRevision(ctx context.Context, revision *model.AchievementRevision) error
+	GetAchievementRevisions(ctx context.Context, mongoID string) ([]model.AchievementRevision, error)
 
 	// PostgreSQL Operations
-	CreateAchievementReference(studentID, mongoID string) (string, error)
+	CreateAchievementReferences(mongoID string, studentIDs []string) ([]string, error)
 	GetAchievementReference(id string) (*model.AchievementReference, error)
 	GetAchievementReferenceByMongoID(mongoID string) (*model.AchievementReference, error)
-	UpdateAchievementStatus(id, status string, submittedAt *time.Time) error
-	VerifyAchievement(id, verifiedBy string) error
-	RejectAchievement(id, note string) error
+	GetAchievementReferencesByMongoID(mongoID string) ([]model.AchievementReference, error)
+	TransitionAchievement(change *model.AchievementTransition) (bool, error)
 	GetAchievementsByStudentID(studentID string) ([]model.AchievementReference, error)
 	GetAchievementReferencesByStudentIDs(studentIDs []string) ([]model.AchievementReference, error)
 	GetAllAchievementReferences() ([]model.AchievementReference, error)
+	GetAchievementReferencesByStatuses(statuses []string) ([]model.AchievementReference, error)
+	GetStatusHistory(refID string) ([]model.AchievementStatusHistory, error)
+	GetOverdueAchievementReferences(studentIDs []string, submittedBefore time.Time) ([]model.AchievementReference, error)
+	GetVerifierTurnaround(studentIDs []string) ([]model.VerifierTurnaround, error)
+	CreateComment(comment *model.AchievementComment) error
+	GetComment(id string) (*model.AchievementComment, error)
+	GetComments(refID string) ([]model.AchievementComment, error)
+	ResolveComment(id string) error
+	GetCommentCounts(refIDs []string) (map[string]model.CommentCount, error)
+	GetAchievementTypes() ([]model.AchievementTypeDefinition, error)
+	GetAchievementType(name string) (*model.AchievementTypeDefinition, error)
+	SaveAchievementType(definition *model.AchievementTypeDefinition) error
+	GetAcademicPeriods() ([]model.AcademicPeriod, error)
+	SaveAcademicPeriod(period *model.AcademicPeriod) error
+	DeleteAcademicPeriod(code string) error
+	CreateTranscriptDocument(doc *model.TranscriptDocument, render func() error) error
+	GetPointsRuleSet() (*model.PointsRuleSet, error)
+	SavePointsRuleSet(rules []model.PointsRule, caps []model.PointsCap, teamPolicy, createdBy string) (*model.PointsRuleSet, error)
+	GetApprovalStages(achievementType, competitionLevel string) ([]model.ApprovalStage, error)
+	GetApprovalChains() ([]model.ApprovalChain, error)
+	SaveApprovalChain(chain model.ApprovalChain) error
 }
 
 func NewAchievementRepository(mongoDB *mongo.Database, sqlDB *sql.DB) *AchievementRepository {
@@ -59,6 +98,7 @@ func (r *AchievementRepository) CreateAchievementMongo(ctx context.Context, achi
 	collection := r.mongoDB.Collection("achievements")
 	achievement.CreatedAt = time.Now()
 	achievement.UpdatedAt = time.Now()
+	achievement.EventAt = achievement.Details.EventTime()
 	achievement.IsDeleted = false
 
 	result, err := collection.InsertOne(ctx, achievement)
@@ -93,21 +133,62 @@ func (r *AchievementRepository) UpdateAchievementMongo(ctx context.Context, mong
 	}
 
 	update.UpdatedAt = time.Now()
-	updateDoc := bson.M{
-		"$set": bson.M{
-			"title":       update.Title,
-			"description": update.Description,
-			"details":     update.Details,
-			"tags":        update.Tags,
-			"points":      update.Points,
-			"updatedAt":   update.UpdatedAt,
-		},
+	set := bson.M{
+		"title":             update.Title,
+		"description":       update.Description,
+		"details":           update.Details,
+		"tags":              update.Tags,
+		"points":            update.Points,
+		"pointsRuleId":      update.PointsRuleID,
+		"pointsRuleVersion": update.PointsVersion,
+		"normalizedTitle":   update.NormalizedTitle,
+		"eventAt":           update.Details.EventTime(),
+		"updatedAt":         update.UpdatedAt,
+	}
+	// Members are fixed at creation; only their point shares change
+	if update.IsTeam() {
+		set["teamMembers"] = update.TeamMembers
+	}
+	// validUntil can only be saved in the future, so an edited achievement is no longer expired
+	updateDoc := bson.M{"$set": set, "$unset": bson.M{"expiredAt": ""}}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objectID}, updateDoc)
+	return err
+}
+
+// UpdateAchievementPointsMongo rescores an achievement without touching its content or updatedAt
+func (r *AchievementRepository) UpdateAchievementPointsMongo(ctx context.Context, mongoID string, points int, ruleID string, version int, teamMembers []model.TeamMember) error {
+	collection := r.mongoDB.Collection("achievements")
+	objectID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	set := bson.M{
+		"points":            points,
+		"pointsRuleId":      ruleID,
+		"pointsRuleVersion": version,
 	}
+	if len(teamMembers) > 0 {
+		set["teamMembers"] = teamMembers
+	}
+	updateDoc := bson.M{"$set": set}
 
 	_, err = collection.UpdateOne(ctx, bson.M{"_id": objectID}, updateDoc)
 	return err
 }
 
+// DeleteAchievementMongo removes a document outright; only for one whose references could not be created
+func (r *AchievementRepository) DeleteAchievementMongo(ctx context.Context, mongoID string) error {
+	objectID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mongoDB.Collection("achievements").DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
 func (r *AchievementRepository) SoftDeleteAchievementMongo(ctx context.Context, mongoID string) error {
 	collection := r.mongoDB.Collection("achievements")
 	objectID, err := primitive.ObjectIDFromHex(mongoID)
@@ -133,7 +214,6 @@ func (r *AchievementRepository) AddAttachmentMongo(ctx context.Context, mongoID
 		return err
 	}
 
-	attachment.UploadedAt = time.Now()
 	updateDoc := bson.M{
 		"$push": bson.M{"attachments": attachment},
 		"$set":  bson.M{"updatedAt": time.Now()},
@@ -143,11 +223,302 @@ func (r *AchievementRepository) AddAttachmentMongo(ctx context.Context, mongoID
 	return err
 }
 
+// FindDuplicateCandidatesMongo returns other live achievements sharing the normalized title,
+// event date, certification number or ISSN; the caller decides which really look alike
+func (r *AchievementRepository) FindDuplicateCandidatesMongo(ctx context.Context, achievement *model.Achievement) ([]model.Achievement, error) {
+	collection := r.mongoDB.Collection("achievements")
+
+	or := bson.A{}
+	if achievement.NormalizedTitle != "" {
+		or = append(or, bson.M{"normalizedTitle": achievement.NormalizedTitle})
+	}
+	// Achievements saved before titles were normalized have no normalizedTitle yet
+	if pattern := looseTextPattern(achievement.Title); pattern != "" {
+		or = append(or, bson.M{
+			"normalizedTitle": bson.M{"$exists": false},
+			"title":           bson.M{"$regex": pattern, "$options": "i"},
+		})
+	}
+	// Same competition on the same day; a bare event date would match every achievement that day
+	if pattern := looseTextPattern(achievement.Details.CompetitionName); pattern != "" && achievement.Details.EventDate != "" {
+		or = append(or, bson.M{
+			"details.eventDate":       achievement.Details.EventDate,
+			"details.competitionName": bson.M{"$regex": pattern, "$options": "i"},
+		})
+	}
+	if achievement.Details.CertificationNumber != "" {
+		or = append(or, bson.M{"details.certificationNumber": achievement.Details.CertificationNumber})
+	}
+	if achievement.Details.ISSN != "" {
+		or = append(or, bson.M{"details.issn": achievement.Details.ISSN})
+	}
+	if len(or) == 0 {
+		return []model.Achievement{}, nil
+	}
+
+	filter := bson.M{
+		"_id":       bson.M{"$ne": achievement.ID},
+		"isDeleted": false,
+		"$or":       or,
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetLimit(50))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	candidates := []model.Achievement{}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// looseTextPattern matches text with the same words in any case, separated by any run of
+// punctuation or spaces; empty when the text has no words
+func looseTextPattern(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	for i := range words {
+		words[i] = regexp.QuoteMeta(words[i])
+	}
+	separator := `[^\p{L}\p{N}]`
+	return "^" + separator + "*" + strings.Join(words, separator+"+") + separator + "*$"
+}
+
+// SetPossibleDuplicatesMongo stores the detector's findings without touching updatedAt
+func (r *AchievementRepository) SetPossibleDuplicatesMongo(ctx context.Context, mongoID string, matches []model.DuplicateMatch) error {
+	collection := r.mongoDB.Collection("achievements")
+	objectID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"possibleDuplicates": matches}})
+	return err
+}
+
+// MarkExpiredAchievementsMongo stamps expiredAt on certifications whose validUntil has passed
+func (r *AchievementRepository) MarkExpiredAchievementsMongo(ctx context.Context, now time.Time) (int64, error) {
+	collection := r.mongoDB.Collection("achievements")
+
+	filter := bson.M{
+		"achievementType":    "certification",
+		"details.validUntil": bson.M{"$lte": now},
+		"expiredAt":          bson.M{"$exists": false},
+		"isDeleted":          false,
+	}
+	result, err := collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"expiredAt": now}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// GetExpiringAchievementsMongo returns certifications valid until a time in (from, until], soonest first;
+// empty studentIDs covers everyone
+func (r *AchievementRepository) GetExpiringAchievementsMongo(ctx context.Context, studentIDs []string, from, until time.Time) ([]model.Achievement, error) {
+	collection := r.mongoDB.Collection("achievements")
+
+	filter := bson.M{
+		"achievementType":    "certification",
+		"details.validUntil": bson.M{"$gt": from, "$lte": until},
+		"expiredAt":          bson.M{"$exists": false},
+		"isDeleted":          false,
+	}
+	if len(studentIDs) > 0 {
+		filter["$or"] = bson.A{
+			bson.M{"studentId": bson.M{"$in": studentIDs}},
+			bson.M{"teamMembers.studentId": bson.M{"$in": studentIDs}},
+		}
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"details.validUntil": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var achievements []model.Achievement
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+// ReplaceAttachmentMongo swaps the attachment with the same ID for a new version
+func (r *AchievementRepository) ReplaceAttachmentMongo(ctx context.Context, mongoID string, attachment model.Attachment) error {
+	collection := r.mongoDB.Collection("achievements")
+	objectID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	updateDoc := bson.M{
+		"$set": bson.M{
+			"attachments.$": attachment,
+			"updatedAt":     time.Now(),
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objectID, "attachments.id": attachment.ID}, updateDoc)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *AchievementRepository) RemoveAttachmentMongo(ctx context.Context, mongoID, attachmentID string) error {
+	collection := r.mongoDB.Collection("achievements")
+	objectID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	updateDoc := bson.M{
+		"$pull": bson.M{"attachments": bson.M{"id": attachmentID}},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objectID, "attachments.id": attachmentID}, updateDoc)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// AssignAttachmentIDsMongo gives attachments stored before IDs existed a stable ID
+func (r *AchievementRepository) AssignAttachmentIDsMongo(ctx context.Context, mongoID string) error {
+	achievement, err := r.GetAchievementMongo(ctx, mongoID)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for i := range achievement.Attachments {
+		if achievement.Attachments[i].ID == "" {
+			achievement.Attachments[i].ID = primitive.NewObjectID().Hex()
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// Only write if the list is still the one we read, so concurrent uploads are not lost
+	collection := r.mongoDB.Collection("achievements")
+	_, err = collection.UpdateOne(ctx,
+		bson.M{"_id": achievement.ID, "updatedAt": achievement.UpdatedAt},
+		bson.M{"$set": bson.M{"attachments": achievement.Attachments}},
+	)
+	return err
+}
+
+// maxRevisionAttempts bounds the retries when concurrent edits race for the same revision number
+const maxRevisionAttempts = 5
+
+// EnsureRevisionIndex makes (achievementId, revision) unique, so two concurrent edits cannot
+// both record the same revision number
+func (r *AchievementRepository) EnsureRevisionIndex(ctx context.Context) error {
+	_, err := r.mongoDB.Collection("achievement_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "achievementId", Value: 1}, {Key: "revision", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// BackfillEventDates stores eventAt on achievements saved before it existed, so period filters
+// see the same date as reports and point caps
+func (r *AchievementRepository) BackfillEventDates(ctx context.Context) error {
+	collection := r.mongoDB.Collection("achievements")
+
+	cursor, err := collection.Find(ctx, bson.M{"eventAt": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"details": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var achievement model.Achievement
+		if err := cursor.Decode(&achievement); err != nil {
+			return err
+		}
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": achievement.ID},
+			bson.M{"$set": bson.M{"eventAt": achievement.Details.EventTime()}})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// CreateAchievementRevision stores a content snapshot with the next revision number
+func (r *AchievementRepository) CreateAchievementRevision(ctx context.Context, revision *model.AchievementRevision) error {
+	collection := r.mongoDB.Collection("achievement_revisions")
+
+	for attempt := 1; ; attempt++ {
+		var latest model.AchievementRevision
+		err := collection.FindOne(ctx, bson.M{"achievementId": revision.AchievementID},
+			options.FindOne().SetSort(bson.M{"revision": -1}).SetProjection(bson.M{"revision": 1}),
+		).Decode(&latest)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+
+		revision.Revision = latest.Revision + 1
+		revision.CreatedAt = time.Now()
+
+		result, err := collection.InsertOne(ctx, revision)
+		if mongo.IsDuplicateKeyError(err) && attempt < maxRevisionAttempts {
+			// Another edit took this number first; read the latest one again
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		revision.ID = result.InsertedID.(primitive.ObjectID)
+		return nil
+	}
+}
+
+func (r *AchievementRepository) GetAchievementRevisions(ctx context.Context, mongoID string) ([]model.AchievementRevision, error) {
+	collection := r.mongoDB.Collection("achievement_revisions")
+
+	cursor, err := collection.Find(ctx, bson.M{"achievementId": mongoID}, options.Find().SetSort(bson.M{"revision": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []model.AchievementRevision{}
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
 func (r *AchievementRepository) GetAchievementsByStudentIDs(ctx context.Context, studentIDs []string) ([]model.Achievement, error) {
 	collection := r.mongoDB.Collection("achievements")
 	
 	filter := bson.M{
-		"studentId": bson.M{"$in": studentIDs},
+		"$or": bson.A{
+			bson.M{"studentId": bson.M{"$in": studentIDs}},
+			bson.M{"teamMembers.studentId": bson.M{"$in": studentIDs}},
+		},
 		"isDeleted": false,
 	}
 	
@@ -177,7 +548,10 @@ func (r *AchievementRepository) GetAchievementsWithFilter(ctx context.Context, f
 	}
 	
 	if filter.StudentID != nil && *filter.StudentID != "" {
-		mongoFilter["studentId"] = *filter.StudentID
+		mongoFilter["$or"] = bson.A{
+			bson.M{"studentId": *filter.StudentID},
+			bson.M{"teamMembers.studentId": *filter.StudentID},
+		}
 	}
 	
 	if filter.DateFrom != nil && *filter.DateFrom != "" {
@@ -194,6 +568,10 @@ func (r *AchievementRepository) GetAchievementsWithFilter(ctx context.Context, f
 		}
 	}
 	
+	if filter.AcademicPeriod != nil {
+		mongoFilter["$and"] = bson.A{periodFilter(filter.AcademicPeriod)}
+	}
+	
 	// Count total
 	total, err := collection.CountDocuments(ctx, mongoFilter)
 	if err != nil {
@@ -224,8 +602,14 @@ func (r *AchievementRepository) GetAchievementsWithFilter(ctx context.Context, f
 	
 	skip := (page - 1) * limit
 	
+	// _id breaks ties so consecutive pages neither repeat nor skip documents
+	sortKeys := bson.D{{Key: sortField, Value: sortOrder}}
+	if sortField != "_id" {
+		sortKeys = append(sortKeys, bson.E{Key: "_id", Value: sortOrder})
+	}
+	
 	opts := options.Find().
-		SetSort(bson.M{sortField: sortOrder}).
+		SetSort(sortKeys).
 		SetSkip(int64(skip)).
 		SetLimit(int64(limit))
 	
@@ -244,13 +628,19 @@ func (r *AchievementRepository) GetAchievementsWithFilter(ctx context.Context, f
 }
 
 // New function for statistics aggregation methods
-func (r *AchievementRepository) GetAchievementStatistics(ctx context.Context, studentIDs []string) (*model.AchievementStatistics, error) {
+func (r *AchievementRepository) GetAchievementStatistics(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) (*model.AchievementStatistics, error) {
 	collection := r.mongoDB.Collection("achievements")
 	
 	// Build filter - if studentIDs provided, filter by them
 	matchFilter := bson.M{"isDeleted": false}
 	if len(studentIDs) > 0 {
-		matchFilter["studentId"] = bson.M{"$in": studentIDs}
+		matchFilter["$or"] = bson.A{
+			bson.M{"studentId": bson.M{"$in": studentIDs}},
+			bson.M{"teamMembers.studentId": bson.M{"$in": studentIDs}},
+		}
+	}
+	if period != nil {
+		matchFilter["$and"] = bson.A{periodFilter(period)}
 	}
 	
 	// Aggregation pipeline for statistics
@@ -273,12 +663,6 @@ func (r *AchievementRepository) GetAchievementStatistics(ctx context.Context, st
 					"count": bson.M{"$sum": 1},
 				}},
 			},
-			"totalPoints": bson.A{
-				bson.M{"$group": bson.M{
-					"_id":         nil,
-					"totalPoints": bson.M{"$sum": "$points"},
-				}},
-			},
 		}}},
 	}
 	
@@ -334,24 +718,33 @@ func (r *AchievementRepository) GetAchievementStatistics(ctx context.Context, st
 				}
 			}
 		}
-		
-		// Total points
-		if totalPoints, ok := result["totalPoints"].(bson.A); ok && len(totalPoints) > 0 {
-			if pointsDoc, ok := totalPoints[0].(bson.M); ok {
-				if points, ok := pointsDoc["totalPoints"].(int32); ok {
-					stats.TotalPoints = int(points)
-				} else if points, ok := pointsDoc["totalPoints"].(int64); ok {
-					stats.TotalPoints = int(points)
-				}
-			}
-		}
 	}
 	
-	// Get status statistics from PostgreSQL
-	statusStats, err := r.getStatusStatistics(studentIDs)
+	// Points only count once verified, so they are bucketed by PostgreSQL status
+	pointsByStudent, periodStatuses, err := r.getPointsByStudent(ctx, studentIDs, period)
+	if err != nil {
+		return nil, err
+	}
+	for _, student := range pointsByStudent {
+		stats.VerifiedPoints += student.VerifiedPoints
+		stats.PendingPoints += student.PendingPoints
+		stats.RejectedPoints += student.RejectedPoints
+		stats.ExpiredPoints += student.ExpiredPoints
+	}
+	
+	// Get status statistics from PostgreSQL; references carry no date, so a period
+	// is applied through the documents matched above
+	statusStats := periodStatuses
+	if period == nil {
+		statusStats, err = r.getStatusStatistics(studentIDs)
+	}
 	if err == nil {
 		stats.ByStatus = statusStats
-		stats.PendingVerification = statusStats["submitted"]
+		for status, count := range statusStats {
+			if model.IsPendingReview(status) {
+				stats.PendingVerification += count
+			}
+		}
 		stats.RecentVerified = statusStats["verified"]
 	}
 	
@@ -405,76 +798,397 @@ func (r *AchievementRepository) getStatusStatistics(studentIDs []string) (map[st
 	return stats, nil
 }
 
-// New function for getting top students by achievement count
-func (r *AchievementRepository) GetTopStudents(ctx context.Context, limit int) ([]model.StudentAchievementCount, error) {
-	collection := r.mongoDB.Collection("achievements")
-	
+// GetStudentPoints sums each student's points by status (all students for nil studentIDs) and
+// lists their verified achievements, so the caller can apply period caps before ranking
+func (r *AchievementRepository) GetStudentPoints(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) ([]model.StudentAchievementCount, error) {
+	pointsByStudent, _, err := r.getPointsByStudent(ctx, studentIDs, period)
+	if err != nil {
+		return nil, err
+	}
+
+	students := make([]model.StudentAchievementCount, 0, len(pointsByStudent))
+	for _, student := range pointsByStudent {
+		students = append(students, *student)
+	}
+	return students, nil
+}
+
+// GetDailyStatistics counts references per day of their achievement's eventAt (createdAt when there
+// is none), per groupBy key (type, level for competitions only, or status) and per reference status.
+// MongoDB groups the achievements by day and key; the statuses of the grouped achievements are then
+// counted in PostgreSQL, so a team achievement counts once per member in scope, as in the statistics.
+// Deleted references are left out.
+func (r *AchievementRepository) GetDailyStatistics(ctx context.Context, studentIDs []string, groupBy string) ([]model.DailyStatistics, error) {
+	matchFilter := bson.M{"isDeleted": false}
+	if len(studentIDs) > 0 {
+		matchFilter["$or"] = bson.A{
+			bson.M{"studentId": bson.M{"$in": studentIDs}},
+			bson.M{"teamMembers.studentId": bson.M{"$in": studentIDs}},
+		}
+	}
+
+	var key interface{}
+	switch groupBy {
+	case "type":
+		key = "$achievementType"
+	case "level":
+		matchFilter["achievementType"] = "competition"
+		key = "$details.competitionLevel"
+	case "status":
+		key = ""
+	default:
+		return nil, fmt.Errorf("unsupported trend group %q", groupBy)
+	}
+
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"isDeleted": false}}},
+		{{Key: "$match", Value: matchFilter}},
 		{{Key: "$group", Value: bson.M{
-			"_id":         "$studentId",
-			"count":       bson.M{"$sum": 1},
-			"totalPoints": bson.M{"$sum": "$points"},
+			"_id": bson.M{
+				"day": bson.M{"$dateToString": bson.M{
+					"format": "%Y-%m-%d",
+					"date":   bson.M{"$ifNull": bson.A{"$eventAt", "$createdAt"}},
+				}},
+				"key": key,
+			},
+			"ids": bson.M{"$push": bson.M{"$toString": "$_id"}},
 		}}},
-		{{Key: "$sort", Value: bson.M{"count": -1}}},
-		{{Key: "$limit", Value: limit}},
 	}
-	
-	cursor, err := collection.Aggregate(ctx, pipeline)
+
+	cursor, err := r.mongoDB.Collection("achievements").Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
-	
-	var topStudents []model.StudentAchievementCount
-	for cursor.Next(ctx) {
-		var result struct {
-			ID          string `bson:"_id"`
-			Count       int32  `bson:"count"`
-			TotalPoints int32  `bson:"totalPoints"`
-		}
-		
-		if err := cursor.Decode(&result); err != nil {
-			continue
-		}
-		
-		// Get student details from PostgreSQL
-		studentName, nim := r.getStudentInfo(result.ID)
-		
-		topStudents = append(topStudents, model.StudentAchievementCount{
-			StudentID:   result.ID,
-			StudentName: studentName,
-			NIM:         nim,
-			Count:       int(result.Count),
-			TotalPoints: int(result.TotalPoints),
-		})
+
+	var groups []struct {
+		ID struct {
+			Day string      `bson:"day"`
+			Key interface{} `bson:"key"`
+		} `bson:"_id"`
+		IDs []string `bson:"ids"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
 	}
-	
-	return topStudents, nil
-}
 
-func (r *AchievementRepository) getStudentInfo(studentID string) (string, string) {
-	query := `
-		SELECT u.full_name, s.student_id
-		FROM students s
-		JOIN users u ON s.user_id = u.id
-		WHERE s.id = $1
-	`
-	
-	var fullName, nim string
-	err := r.sqlDB.QueryRow(query, studentID).Scan(&fullName, &nim)
+	var mongoIDs []string
+	for _, group := range groups {
+		mongoIDs = append(mongoIDs, group.IDs...)
+	}
+	statuses, err := r.getReferenceStatusCounts(mongoIDs, studentIDs)
 	if err != nil {
-		return "Unknown", "Unknown"
+		return nil, err
 	}
-	
-	return fullName, nim
-}
-
-func (r *AchievementRepository) GetStudentInfo(studentID string) (string, string) {
-	return r.getStudentInfo(studentID)
-}
 
-func (r *AchievementRepository) GetAchievementReference(id string) (*model.AchievementReference, error) {
+	results := []model.DailyStatistics{}
+	for _, group := range groups {
+		key, _ := group.ID.Key.(string)
+		counts := make(map[string]int)
+		var order []string
+		for _, id := range group.IDs {
+			for _, status := range statuses[id] {
+				if _, seen := counts[status.status]; !seen {
+					order = append(order, status.status)
+				}
+				counts[status.status] += status.count
+			}
+		}
+		for _, status := range order {
+			row := model.DailyStatistics{Day: group.ID.Day, Key: key, Status: status, Count: counts[status]}
+			if groupBy == "status" {
+				row.Key = status
+			}
+			results = append(results, row)
+		}
+	}
+	return results, nil
+}
+
+type statusCount struct {
+	status string
+	count  int
+}
+
+// getReferenceStatusCounts counts the non-deleted references of each of the Mongo achievements by
+// status, only those belonging to the students when studentIDs is not empty. The ids are sent a page
+// at a time so no single query grows with the size of the collection.
+func (r *AchievementRepository) getReferenceStatusCounts(mongoIDs, studentIDs []string) (map[string][]statusCount, error) {
+	args := []interface{}{nil}
+	studentFilter := ""
+	if len(studentIDs) > 0 {
+		placeholders := ""
+		for i, id := range studentIDs {
+			if i > 0 {
+				placeholders += ", "
+			}
+			placeholders += fmt.Sprintf("$%d", i+2)
+			args = append(args, id)
+		}
+		studentFilter = fmt.Sprintf(" AND student_id IN (%s)", placeholders)
+	}
+
+	statuses := make(map[string][]statusCount)
+	for start := 0; start < len(mongoIDs); start += pointsPageSize {
+		end := start + pointsPageSize
+		if end > len(mongoIDs) {
+			end = len(mongoIDs)
+		}
+		args[0] = pq.Array(mongoIDs[start:end])
+
+		rows, err := r.sqlDB.Query(`
+			SELECT mongo_achievement_id, status, COUNT(*)
+			FROM achievement_references
+			WHERE status <> 'deleted' AND mongo_achievement_id = ANY($1)`+studentFilter+`
+			GROUP BY mongo_achievement_id, status
+		`, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var mongoID string
+			var count statusCount
+			if err := rows.Scan(&mongoID, &count.status, &count.count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			statuses[mongoID] = append(statuses[mongoID], count)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+// pointsPageSize bounds how many references, and so how many ids in the MongoDB $in, one round trip loads
+const pointsPageSize = 1000
+
+// getPointsByStudent joins MongoDB points with PostgreSQL statuses and sums them per student,
+// a page of references at a time. It also counts the joined references by status, which covers
+// only the period when one is given.
+func (r *AchievementRepository) getPointsByStudent(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) (map[string]*model.StudentAchievementCount, map[string]int, error) {
+	result := make(map[string]*model.StudentAchievementCount)
+	statusCounts := make(map[string]int)
+
+	var args []interface{}
+	studentFilter := ""
+	if len(studentIDs) > 0 {
+		placeholders := ""
+		for i, id := range studentIDs {
+			if i > 0 {
+				placeholders += ", "
+			}
+			placeholders += fmt.Sprintf("$%d", i+1)
+			args = append(args, id)
+		}
+		studentFilter = fmt.Sprintf(" AND student_id IN (%s)", placeholders)
+	}
+
+	// Keyset pagination on the primary key keeps every page an index range scan
+	lastID := ""
+	for {
+		query := `
+			SELECT id::text, student_id, mongo_achievement_id, status
+			FROM achievement_references
+			WHERE status <> 'deleted'` + studentFilter
+		pageArgs := args
+		if lastID != "" {
+			query += fmt.Sprintf(" AND id > $%d", len(args)+1)
+			pageArgs = append(append([]interface{}{}, args...), lastID)
+		}
+		query += fmt.Sprintf(" ORDER BY id LIMIT %d", pointsPageSize)
+
+		rows, err := r.sqlDB.Query(query, pageArgs...)
+		if err != nil {
+			return nil, nil, err
+		}
+		page, lastRef, err := scanPointsPage(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := r.addPointsPage(ctx, page, period, result, statusCounts); err != nil {
+			return nil, nil, err
+		}
+
+		if page.refs < pointsPageSize {
+			return result, statusCounts, nil
+		}
+		lastID = lastRef
+	}
+}
+
+type refStatus struct {
+	studentID string
+	status    string
+}
+
+// pointsPage is one page of references grouped by MongoDB achievement; team achievements
+// have one reference per member
+type pointsPage struct {
+	refs      int
+	statuses  map[string][]refStatus
+	objectIDs []primitive.ObjectID
+}
+
+// scanPointsPage reads a page of references and returns it with the id of its last reference
+func scanPointsPage(rows *sql.Rows) (*pointsPage, string, error) {
+	defer rows.Close()
+
+	page := &pointsPage{statuses: make(map[string][]refStatus)}
+	lastID := ""
+	for rows.Next() {
+		var id, studentID, mongoID, status string
+		if err := rows.Scan(&id, &studentID, &mongoID, &status); err != nil {
+			return nil, "", err
+		}
+		page.refs++
+		lastID = id
+
+		objectID, err := primitive.ObjectIDFromHex(mongoID)
+		if err != nil {
+			continue
+		}
+		if _, seen := page.statuses[mongoID]; !seen {
+			page.objectIDs = append(page.objectIDs, objectID)
+		}
+		page.statuses[mongoID] = append(page.statuses[mongoID], refStatus{studentID: studentID, status: status})
+	}
+	return page, lastID, rows.Err()
+}
+
+// addPointsPage adds the points of a page of references to the per-student totals
+func (r *AchievementRepository) addPointsPage(ctx context.Context, page *pointsPage, period *model.AcademicPeriod, result map[string]*model.StudentAchievementCount, statusCounts map[string]int) error {
+	if len(page.objectIDs) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": page.objectIDs}, "isDeleted": false}
+	if period != nil {
+		filter["$and"] = bson.A{periodFilter(period)}
+	}
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx, filter,
+		options.Find().SetProjection(bson.M{
+			"points": 1, "teamMembers": 1, "expiredAt": 1, "achievementType": 1,
+			"details.eventDate": 1, "details.period": 1, "createdAt": 1,
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc model.Achievement
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+
+		for _, ref := range page.statuses[doc.ID.Hex()] {
+			statusCounts[ref.status]++
+			student, exists := result[ref.studentID]
+			if !exists {
+				student = &model.StudentAchievementCount{StudentID: ref.studentID}
+				result[ref.studentID] = student
+			}
+			student.Add(ref.status, doc.PointsFor(ref.studentID))
+			if doc.ExpiredAt != nil {
+				student.AddExpired(ref.status, doc.PointsFor(ref.studentID))
+			}
+			if ref.status == "verified" {
+				student.Count++
+				verified := doc
+				verified.Points = doc.PointsFor(ref.studentID)
+				student.Verified = append(student.Verified, verified)
+			}
+			student.TotalPoints = student.VerifiedPoints
+		}
+	}
+
+	return cursor.Err()
+}
+
+// periodFilter matches achievements whose ReportDate falls in the period: eventAt, or createdAt
+// when there is none (null or, before the backfill, missing)
+func periodFilter(period *model.AcademicPeriod) bson.M {
+	start, end := period.StartDate, period.EndExclusive()
+	return bson.M{"$or": bson.A{
+		bson.M{"eventAt": bson.M{"$gte": start, "$lt": end}},
+		bson.M{
+			"eventAt":   nil,
+			"createdAt": bson.M{"$gte": start, "$lt": end},
+		},
+	}}
+}
+
+func (r *AchievementRepository) getStudentInfo(studentID string) (string, string) {
+	query := `
+		SELECT u.full_name, s.student_id
+		FROM students s
+		JOIN users u ON s.user_id = u.id
+		WHERE s.id = $1
+	`
+	
+	var fullName, nim string
+	err := r.sqlDB.QueryRow(query, studentID).Scan(&fullName, &nim)
+	if err != nil {
+		return "Unknown", "Unknown"
+	}
+	
+	return fullName, nim
+}
+
+func (r *AchievementRepository) GetStudentInfo(studentID string) (string, string) {
+	return r.getStudentInfo(studentID)
+}
+
+// StudentExists reports whether a student profile with the ID exists
+func (r *AchievementRepository) StudentExists(studentID string) (bool, error) {
+	var exists bool
+	err := r.sqlDB.QueryRow(`SELECT EXISTS (SELECT 1 FROM students WHERE id::text = $1)`, studentID).Scan(&exists)
+	return exists, err
+}
+
+// GetUserNames maps user IDs (e.g. verifiers) to full names; unknown IDs are left out
+func (r *AchievementRepository) GetUserNames(userIDs []string) (map[string]string, error) {
+	names := make(map[string]string)
+	if len(userIDs) == 0 {
+		return names, nil
+	}
+
+	placeholders := ""
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+	query := fmt.Sprintf(`
+		SELECT id::text, full_name
+		FROM users
+		WHERE id::text IN (%s)
+	`, placeholders)
+
+	rows, err := r.sqlDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, fullName string
+		if err := rows.Scan(&id, &fullName); err != nil {
+			return nil, err
+		}
+		names[id] = fullName
+	}
+	return names, rows.Err()
+}
+
+func (r *AchievementRepository) GetAchievementReference(id string) (*model.AchievementReference, error) {
 	query := `
 		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, 
 		       verified_by, rejection_note, created_at, updated_at
@@ -499,6 +1213,8 @@ func (r *AchievementRepository) GetAchievementReferenceByMongoID(mongoID string)
 		       verified_by, rejection_note, created_at, updated_at
 		FROM achievement_references
 		WHERE mongo_achievement_id = $1
+		ORDER BY created_at
+		LIMIT 1
 	`
 	var ref model.AchievementReference
 	err := r.sqlDB.QueryRow(query, mongoID).Scan(
@@ -512,35 +1228,87 @@ func (r *AchievementRepository) GetAchievementReferenceByMongoID(mongoID string)
 	return &ref, nil
 }
 
-func (r *AchievementRepository) UpdateAchievementStatus(id, status string, submittedAt *time.Time) error {
-	query := `
-		UPDATE achievement_references
-		SET status = $1, submitted_at = $2, updated_at = NOW()
-		WHERE id = $3
-	`
-	_, err := r.sqlDB.Exec(query, status, submittedAt, id)
-	return err
-}
+// TransitionAchievement moves the reference to History.ToStatus only if it is still in
+// History.FromStatus and records the history row in the same transaction. It reports false,
+// changing nothing, when a concurrent change got there first.
+func (r *AchievementRepository) TransitionAchievement(change *model.AchievementTransition) (bool, error) {
+	tx, err := r.sqlDB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
 
-func (r *AchievementRepository) VerifyAchievement(id, verifiedBy string) error {
-	now := time.Now()
-	query := `
+	history := &change.History
+	result, err := tx.Exec(`
 		UPDATE achievement_references
-		SET status = 'verified', verified_at = $1, verified_by = $2, updated_at = NOW()
-		WHERE id = $3
-	`
-	_, err := r.sqlDB.Exec(query, now, verifiedBy, id)
-	return err
+		SET status = $1, submitted_at = $2, rejection_note = $3,
+		    verified_at = CASE WHEN $4 THEN NOW() ELSE verified_at END,
+		    verified_by = CASE WHEN $4 THEN $5 ELSE verified_by END,
+		    updated_at = NOW()
+		WHERE id = $6 AND status = $7
+	`, history.ToStatus, change.SubmittedAt, change.RejectionNote,
+		change.VerifiedBy != nil, change.VerifiedBy,
+		history.AchievementReferenceID, history.FromStatus)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO achievement_status_history (id, achievement_ref_id, from_status, to_status, actor_id, actor_role, note, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`, history.AchievementReferenceID, history.FromStatus, history.ToStatus,
+		history.ActorID, history.ActorRole, history.Note,
+	).Scan(&history.ID, &history.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record status history: %w", err)
+	}
+
+	if change.Comment != nil {
+		if err := insertComment(tx, change.Comment); err != nil {
+			return false, fmt.Errorf("failed to save comment: %w", err)
+		}
+	}
+
+	return true, tx.Commit()
 }
 
-func (r *AchievementRepository) RejectAchievement(id, note string) error {
+// GetAchievementReferencesByMongoID returns every member's reference to a (team) achievement, creator first
+func (r *AchievementRepository) GetAchievementReferencesByMongoID(mongoID string) ([]model.AchievementReference, error) {
 	query := `
-		UPDATE achievement_references
-		SET status = 'rejected', rejection_note = $1, updated_at = NOW()
-		WHERE id = $2
+		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at,
+		       verified_by, rejection_note, created_at, updated_at
+		FROM achievement_references
+		WHERE mongo_achievement_id = $1
+		ORDER BY created_at
 	`
-	_, err := r.sqlDB.Exec(query, note, id)
-	return err
+	rows, err := r.sqlDB.Query(query, mongoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []model.AchievementReference
+	for rows.Next() {
+		var ref model.AchievementReference
+		err := rows.Scan(
+			&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
+			&ref.SubmittedAt, &ref.VerifiedAt, &ref.VerifiedBy, &ref.RejectionNote,
+			&ref.CreatedAt, &ref.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
 }
 
 func (r *AchievementRepository) GetAchievementsByStudentID(studentID string) ([]model.AchievementReference, error) {
@@ -648,13 +1416,714 @@ func (r *AchievementRepository) GetAllAchievementReferences() ([]model.Achieveme
 	return refs, nil
 }
 
-func (r *AchievementRepository) CreateAchievementReference(studentID, mongoID string) (string, error) {
+// CreateAchievementReferences creates a draft reference to the document for each student, all or
+// none, so a team is never left half-created. The ids come back in studentIDs order, and
+// clock_timestamp keeps that order in created_at, which puts the creator first.
+func (r *AchievementRepository) CreateAchievementReferences(mongoID string, studentIDs []string) ([]string, error) {
+	tx, err := r.sqlDB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, len(studentIDs))
+	for i, studentID := range studentIDs {
+		err := tx.QueryRow(`
+			INSERT INTO achievement_references (id, student_id, mongo_achievement_id, status, created_at, updated_at)
+			VALUES (gen_random_uuid(), $1, $2, 'draft', clock_timestamp(), clock_timestamp())
+			RETURNING id
+		`, studentID, mongoID).Scan(&ids[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reference for student %s: %w", studentID, err)
+		}
+	}
+
+	return ids, tx.Commit()
+}
+
+func (r *AchievementRepository) GetStatusHistory(refID string) ([]model.AchievementStatusHistory, error) {
 	query := `
-		INSERT INTO achievement_references (id, student_id, mongo_achievement_id, status, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, 'draft', NOW(), NOW())
-		RETURNING id
+		SELECT id, achievement_ref_id, from_status, to_status, actor_id, actor_role, note, created_at
+		FROM achievement_status_history
+		WHERE achievement_ref_id = $1
+		ORDER BY created_at ASC
 	`
-	var id string
-	err := r.sqlDB.QueryRow(query, studentID, mongoID).Scan(&id)
-	return id, err
+	rows, err := r.sqlDB.Query(query, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []model.AchievementStatusHistory{}
+	for rows.Next() {
+		var h model.AchievementStatusHistory
+		err := rows.Scan(
+			&h.ID, &h.AchievementReferenceID, &h.FromStatus, &h.ToStatus,
+			&h.ActorID, &h.ActorRole, &h.Note, &h.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+func (r *AchievementRepository) GetAchievementReferencesByStatuses(statuses []string) ([]model.AchievementReference, error) {
+	if len(statuses) == 0 {
+		return []model.AchievementReference{}, nil
+	}
+
+	placeholders := ""
+	args := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += fmt.Sprintf("$%d", i+1)
+		args[i] = status
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at,
+		       verified_by, rejection_note, created_at, updated_at
+		FROM achievement_references
+		WHERE status IN (%s)
+		ORDER BY submitted_at ASC
+	`, placeholders)
+
+	rows, err := r.sqlDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []model.AchievementReference
+	for rows.Next() {
+		var ref model.AchievementReference
+		err := rows.Scan(
+			&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
+			&ref.SubmittedAt, &ref.VerifiedAt, &ref.VerifiedBy, &ref.RejectionNote,
+			&ref.CreatedAt, &ref.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// GetApprovalStages returns the chain for a type and level, falling back to the type-wide chain
+func (r *AchievementRepository) GetApprovalStages(achievementType, competitionLevel string) ([]model.ApprovalStage, error) {
+	query := `
+		SELECT stage_order, stage_name, permission, advisor_only
+		FROM approval_chain_stages
+		WHERE achievement_type = $1 AND competition_level = $2
+		ORDER BY stage_order ASC
+	`
+
+	for _, level := range []string{competitionLevel, ""} {
+		rows, err := r.sqlDB.Query(query, achievementType, level)
+		if err != nil {
+			return nil, err
+		}
+
+		var stages []model.ApprovalStage
+		for rows.Next() {
+			var stage model.ApprovalStage
+			if err := rows.Scan(&stage.Order, &stage.Name, &stage.Permission, &stage.AdvisorOnly); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			stages = append(stages, stage)
+		}
+		rows.Close()
+
+		if len(stages) > 0 {
+			return stages, nil
+		}
+	}
+
+	return []model.ApprovalStage{}, nil
+}
+
+func (r *AchievementRepository) GetApprovalChains() ([]model.ApprovalChain, error) {
+	rows, err := r.sqlDB.Query(`
+		SELECT achievement_type, competition_level, stage_order, stage_name, permission, advisor_only
+		FROM approval_chain_stages
+		ORDER BY achievement_type, competition_level, stage_order
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chains := []model.ApprovalChain{}
+	for rows.Next() {
+		var achievementType, level string
+		var stage model.ApprovalStage
+		if err := rows.Scan(&achievementType, &level, &stage.Order, &stage.Name, &stage.Permission, &stage.AdvisorOnly); err != nil {
+			return nil, err
+		}
+
+		last := len(chains) - 1
+		if last < 0 || chains[last].AchievementType != achievementType || chains[last].CompetitionLevel != level {
+			chains = append(chains, model.ApprovalChain{AchievementType: achievementType, CompetitionLevel: level})
+			last++
+		}
+		chains[last].Stages = append(chains[last].Stages, stage)
+	}
+
+	return chains, rows.Err()
+}
+
+// SaveApprovalChain replaces every stage of the chain for a type and level
+func (r *AchievementRepository) SaveApprovalChain(chain model.ApprovalChain) error {
+	tx, err := r.sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM approval_chain_stages
+		WHERE achievement_type = $1 AND competition_level = $2
+	`, chain.AchievementType, chain.CompetitionLevel)
+	if err != nil {
+		return err
+	}
+
+	for i, stage := range chain.Stages {
+		_, err = tx.Exec(`
+			INSERT INTO approval_chain_stages (achievement_type, competition_level, stage_order, stage_name, permission, advisor_only)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, chain.AchievementType, chain.CompetitionLevel, i+1, stage.Name, stage.Permission, stage.AdvisorOnly)
+		if err != nil {
+			return fmt.Errorf("error saving stage %s: %w", stage.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *AchievementRepository) CreateComment(comment *model.AchievementComment) error {
+	return insertComment(r.sqlDB, comment)
+}
+
+// insertComment writes a comment on the database or inside a caller's transaction
+func insertComment(db rowQuerier, comment *model.AchievementComment) error {
+	query := `
+		INSERT INTO achievement_comments (id, achievement_ref_id, parent_id, author_id, author_role, kind, body, resolved, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, FALSE, NOW())
+		RETURNING id, created_at
+	`
+	return db.QueryRow(query,
+		comment.AchievementReferenceID, comment.ParentID, comment.AuthorID,
+		comment.AuthorRole, comment.Kind, comment.Body,
+	).Scan(&comment.ID, &comment.CreatedAt)
+}
+
+func (r *AchievementRepository) GetComment(id string) (*model.AchievementComment, error) {
+	query := `
+		SELECT id, achievement_ref_id, parent_id, author_id, author_role, kind, body, resolved, created_at
+		FROM achievement_comments
+		WHERE id = $1
+	`
+	var c model.AchievementComment
+	err := r.sqlDB.QueryRow(query, id).Scan(
+		&c.ID, &c.AchievementReferenceID, &c.ParentID, &c.AuthorID,
+		&c.AuthorRole, &c.Kind, &c.Body, &c.Resolved, &c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *AchievementRepository) GetComments(refID string) ([]model.AchievementComment, error) {
+	query := `
+		SELECT id, achievement_ref_id, parent_id, author_id, author_role, kind, body, resolved, created_at
+		FROM achievement_comments
+		WHERE achievement_ref_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.sqlDB.Query(query, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []model.AchievementComment{}
+	for rows.Next() {
+		var c model.AchievementComment
+		err := rows.Scan(
+			&c.ID, &c.AchievementReferenceID, &c.ParentID, &c.AuthorID,
+			&c.AuthorRole, &c.Kind, &c.Body, &c.Resolved, &c.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+// ResolveComment marks a whole thread (root comment and its replies) as resolved
+func (r *AchievementRepository) ResolveComment(id string) error {
+	_, err := r.sqlDB.Exec(`
+		UPDATE achievement_comments SET resolved = TRUE
+		WHERE id = $1 OR parent_id = $1
+	`, id)
+	return err
+}
+
+// GetCommentCounts returns total comments and unresolved threads per achievement reference
+func (r *AchievementRepository) GetCommentCounts(refIDs []string) (map[string]model.CommentCount, error) {
+	counts := make(map[string]model.CommentCount)
+	if len(refIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := ""
+	args := make([]interface{}, len(refIDs))
+	for i, id := range refIDs {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT achievement_ref_id,
+		       COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE parent_id IS NULL AND resolved = FALSE) AS unresolved
+		FROM achievement_comments
+		WHERE achievement_ref_id IN (%s)
+		GROUP BY achievement_ref_id
+	`, placeholders)
+
+	rows, err := r.sqlDB.Query(query, args...)
+	if err != nil {
+		return counts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var refID string
+		var count model.CommentCount
+		if err := rows.Scan(&refID, &count.Total, &count.Unresolved); err != nil {
+			return counts, err
+		}
+		counts[refID] = count
+	}
+	return counts, rows.Err()
+}
+
+// GetOverdueAchievementReferences returns achievements still awaiting review that were submitted before the cutoff
+func (r *AchievementRepository) GetOverdueAchievementReferences(studentIDs []string, submittedBefore time.Time) ([]model.AchievementReference, error) {
+	args := []interface{}{submittedBefore}
+	studentFilter := ""
+	if len(studentIDs) > 0 {
+		placeholders := ""
+		for i, id := range studentIDs {
+			if i > 0 {
+				placeholders += ", "
+			}
+			placeholders += fmt.Sprintf("$%d", i+2)
+			args = append(args, id)
+		}
+		studentFilter = fmt.Sprintf("AND student_id IN (%s)", placeholders)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at,
+		       verified_by, rejection_note, created_at, updated_at
+		FROM achievement_references
+		WHERE (status = 'submitted' OR status LIKE '%%\_approved')
+		  AND submitted_at < $1
+		  %s
+		ORDER BY submitted_at ASC
+	`, studentFilter)
+
+	rows, err := r.sqlDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []model.AchievementReference
+	for rows.Next() {
+		var ref model.AchievementReference
+		err := rows.Scan(
+			&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
+			&ref.SubmittedAt, &ref.VerifiedAt, &ref.VerifiedBy, &ref.RejectionNote,
+			&ref.CreatedAt, &ref.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// GetVerifierTurnaround returns the average submitted_at -> verified_at time per verifier
+func (r *AchievementRepository) GetVerifierTurnaround(studentIDs []string) ([]model.VerifierTurnaround, error) {
+	var args []interface{}
+	studentFilter := ""
+	if len(studentIDs) > 0 {
+		placeholders := ""
+		for i, id := range studentIDs {
+			if i > 0 {
+				placeholders += ", "
+			}
+			placeholders += fmt.Sprintf("$%d", i+1)
+			args = append(args, id)
+		}
+		studentFilter = fmt.Sprintf("AND ar.student_id IN (%s)", placeholders)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT ar.verified_by, COALESCE(u.full_name, ''), COUNT(*),
+		       AVG(EXTRACT(EPOCH FROM (ar.verified_at - ar.submitted_at))) / 3600
+		FROM achievement_references ar
+		LEFT JOIN users u ON u.id::text = ar.verified_by
+		WHERE ar.status = 'verified'
+		  AND ar.verified_by IS NOT NULL
+		  AND ar.submitted_at IS NOT NULL
+		  AND ar.verified_at IS NOT NULL
+		  %s
+		GROUP BY ar.verified_by, u.full_name
+		ORDER BY ar.verified_by
+	`, studentFilter)
+
+	rows, err := r.sqlDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	turnaround := []model.VerifierTurnaround{}
+	for rows.Next() {
+		var t model.VerifierTurnaround
+		if err := rows.Scan(&t.VerifierID, &t.VerifierName, &t.Verified, &t.AvgHours); err != nil {
+			return nil, err
+		}
+		turnaround = append(turnaround, t)
+	}
+	return turnaround, rows.Err()
+}
+
+// GetPointsRuleSet returns the latest saved rule table; version 0 with no rules means none was saved yet
+func (r *AchievementRepository) GetPointsRuleSet() (*model.PointsRuleSet, error) {
+	ruleSet := &model.PointsRuleSet{Rules: []model.PointsRule{}, Caps: []model.PointsCap{}}
+
+	var createdAt time.Time
+	err := r.sqlDB.QueryRow(`
+		SELECT version, team_policy, created_by, created_at
+		FROM points_rule_versions
+		ORDER BY version DESC
+		LIMIT 1
+	`).Scan(&ruleSet.Version, &ruleSet.TeamPolicy, &ruleSet.CreatedBy, &createdAt)
+	if err == sql.ErrNoRows {
+		return ruleSet, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ruleSet.CreatedAt = &createdAt
+
+	rows, err := r.sqlDB.Query(`
+		SELECT id, achievement_type, competition_level, medal_type, publication_type, min_rank, max_rank, points
+		FROM points_rules
+		WHERE version = $1
+		ORDER BY position
+	`, ruleSet.Version)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rule model.PointsRule
+		err := rows.Scan(
+			&rule.ID, &rule.AchievementType, &rule.CompetitionLevel, &rule.MedalType,
+			&rule.PublicationType, &rule.MinRank, &rule.MaxRank, &rule.Points,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ruleSet.Rules = append(ruleSet.Rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	capRows, err := r.sqlDB.Query(`
+		SELECT achievement_type, period, max_points
+		FROM points_caps
+		WHERE version = $1
+		ORDER BY achievement_type
+	`, ruleSet.Version)
+	if err != nil {
+		return nil, err
+	}
+	defer capRows.Close()
+
+	for capRows.Next() {
+		var c model.PointsCap
+		if err := capRows.Scan(&c.AchievementType, &c.Period, &c.MaxPoints); err != nil {
+			return nil, err
+		}
+		ruleSet.Caps = append(ruleSet.Caps, c)
+	}
+	return ruleSet, capRows.Err()
+}
+
+// SavePointsRuleSet stores the rules and caps as a new version; older versions are kept for audit
+func (r *AchievementRepository) SavePointsRuleSet(rules []model.PointsRule, caps []model.PointsCap, teamPolicy, createdBy string) (*model.PointsRuleSet, error) {
+	tx, err := r.sqlDB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ruleSet := &model.PointsRuleSet{CreatedBy: createdBy, Caps: []model.PointsCap{}, TeamPolicy: teamPolicy}
+	var createdAt time.Time
+	err = tx.QueryRow(`
+		INSERT INTO points_rule_versions (created_by, team_policy, created_at)
+		VALUES ($1, $2, NOW())
+		RETURNING version, created_at
+	`, createdBy, teamPolicy).Scan(&ruleSet.Version, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	ruleSet.CreatedAt = &createdAt
+
+	for i, rule := range rules {
+		err := tx.QueryRow(`
+			INSERT INTO points_rules (version, position, achievement_type, competition_level, medal_type,
+			                          publication_type, min_rank, max_rank, points)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id
+		`, ruleSet.Version, i+1, rule.AchievementType, rule.CompetitionLevel, rule.MedalType,
+			rule.PublicationType, rule.MinRank, rule.MaxRank, rule.Points).Scan(&rule.ID)
+		if err != nil {
+			return nil, err
+		}
+		ruleSet.Rules = append(ruleSet.Rules, rule)
+	}
+
+	for _, c := range caps {
+		_, err := tx.Exec(`
+			INSERT INTO points_caps (version, achievement_type, period, max_points)
+			VALUES ($1, $2, $3, $4)
+		`, ruleSet.Version, c.AchievementType, c.Period, c.MaxPoints)
+		if err != nil {
+			return nil, err
+		}
+		ruleSet.Caps = append(ruleSet.Caps, c)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ruleSet, nil
+}
+
+func (r *AchievementRepository) GetAchievementTypes() ([]model.AchievementTypeDefinition, error) {
+	rows, err := r.sqlDB.Query(`
+		SELECT name, description, required_fields, custom_fields_schema, default_points, evidence_requirements, is_active, created_at, updated_at
+		FROM achievement_types
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := []model.AchievementTypeDefinition{}
+	for rows.Next() {
+		definition, err := scanAchievementType(rows)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, *definition)
+	}
+	return definitions, rows.Err()
+}
+
+func (r *AchievementRepository) GetAchievementType(name string) (*model.AchievementTypeDefinition, error) {
+	row := r.sqlDB.QueryRow(`
+		SELECT name, description, required_fields, custom_fields_schema, default_points, evidence_requirements, is_active, created_at, updated_at
+		FROM achievement_types
+		WHERE name = $1
+	`, name)
+	return scanAchievementType(row)
+}
+
+// SaveAchievementType creates or replaces a type definition
+func (r *AchievementRepository) SaveAchievementType(definition *model.AchievementTypeDefinition) error {
+	var schema []byte
+	if definition.CustomFieldsSchema != nil {
+		encoded, err := json.Marshal(definition.CustomFieldsSchema)
+		if err != nil {
+			return err
+		}
+		schema = encoded
+	}
+
+	requirements := definition.EvidenceRequirements
+	if requirements == nil {
+		requirements = []model.EvidenceRequirement{}
+	}
+	evidence, err := json.Marshal(requirements)
+	if err != nil {
+		return err
+	}
+
+	var createdAt, updatedAt time.Time
+	err = r.sqlDB.QueryRow(`
+		INSERT INTO achievement_types (name, description, required_fields, custom_fields_schema, default_points, evidence_requirements, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			description = EXCLUDED.description,
+			required_fields = EXCLUDED.required_fields,
+			custom_fields_schema = EXCLUDED.custom_fields_schema,
+			default_points = EXCLUDED.default_points,
+			evidence_requirements = EXCLUDED.evidence_requirements,
+			is_active = EXCLUDED.is_active,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, definition.Name, definition.Description, pq.Array(definition.RequiredFields), schema,
+		definition.DefaultPoints, evidence, definition.IsActive).Scan(&createdAt, &updatedAt)
+	if err != nil {
+		return err
+	}
+
+	definition.CreatedAt = &createdAt
+	definition.UpdatedAt = &updatedAt
+	return nil
+}
+
+func (r *AchievementRepository) GetAcademicPeriods() ([]model.AcademicPeriod, error) {
+	rows, err := r.sqlDB.Query(`
+		SELECT code, name, academic_year, semester, start_date, end_date, created_at, updated_at
+		FROM academic_periods
+		ORDER BY start_date
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []model.AcademicPeriod{}
+	for rows.Next() {
+		var period model.AcademicPeriod
+		var createdAt, updatedAt time.Time
+		err := rows.Scan(
+			&period.Code, &period.Name, &period.AcademicYear, &period.Semester,
+			&period.StartDate, &period.EndDate, &createdAt, &updatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		period.CreatedAt = &createdAt
+		period.UpdatedAt = &updatedAt
+		periods = append(periods, period)
+	}
+	return periods, rows.Err()
+}
+
+// SaveAcademicPeriod creates or replaces a period
+func (r *AchievementRepository) SaveAcademicPeriod(period *model.AcademicPeriod) error {
+	var createdAt, updatedAt time.Time
+	err := r.sqlDB.QueryRow(`
+		INSERT INTO academic_periods (code, name, academic_year, semester, start_date, end_date, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			academic_year = EXCLUDED.academic_year,
+			semester = EXCLUDED.semester,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, period.Code, period.Name, period.AcademicYear, period.Semester, period.StartDate, period.EndDate).Scan(&createdAt, &updatedAt)
+	if err != nil {
+		return err
+	}
+
+	period.CreatedAt = &createdAt
+	period.UpdatedAt = &updatedAt
+	return nil
+}
+
+func (r *AchievementRepository) DeleteAcademicPeriod(code string) error {
+	_, err := r.sqlDB.Exec(`DELETE FROM academic_periods WHERE code = $1`, code)
+	return err
+}
+
+// CreateTranscriptDocument records an issued transcript; PostgreSQL assigns the ID, the
+// document number (SKPI/<year>/<sequence>) and the issue time. render runs with those filled in
+// before the record is committed, and an error from it rolls the record back.
+func (r *AchievementRepository) CreateTranscriptDocument(doc *model.TranscriptDocument, render func() error) error {
+	tx, err := r.sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO transcript_documents (document_number, student_id, period, verified_achievements, total_points, issued_by)
+		VALUES ('SKPI/' || to_char(NOW(), 'YYYY') || '/' || lpad(nextval('transcript_document_seq')::text, 6, '0'), $1, $2, $3, $4, $5)
+		RETURNING id, document_number, issued_at
+	`, doc.StudentID, doc.Period, doc.VerifiedAchievements, doc.TotalPoints, doc.IssuedBy).Scan(&doc.ID, &doc.Number, &doc.IssuedAt)
+	if err != nil {
+		return err
+	}
+	if err := render(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func scanAchievementType(row rowScanner) (*model.AchievementTypeDefinition, error) {
+	var definition model.AchievementTypeDefinition
+	var requiredFields pq.StringArray
+	var schema, evidence []byte
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&definition.Name, &definition.Description, &requiredFields, &schema,
+		&definition.DefaultPoints, &evidence, &definition.IsActive, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	definition.RequiredFields = []string(requiredFields)
+	if len(schema) > 0 {
+		if err := json.Unmarshal(schema, &definition.CustomFieldsSchema); err != nil {
+			return nil, fmt.Errorf("invalid custom fields schema for %s: %w", definition.Name, err)
+		}
+	}
+	if err := json.Unmarshal(evidence, &definition.EvidenceRequirements); err != nil {
+		return nil, fmt.Errorf("invalid evidence requirements for %s: %w", definition.Name, err)
+	}
+	definition.CreatedAt = &createdAt
+	definition.UpdatedAt = &updatedAt
+	return &definition, nil
 }
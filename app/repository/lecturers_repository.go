@@ -3,8 +3,23 @@ package repository
 import (
 	"database/sql"
 	"student-report/app/model"
+	"time"
 )
 
+// ILecturerRepository defines the lecturer operations used by the lecturer service and the verification authorizer
+type ILecturerRepository interface {
+	GetLecturersRepository() ([]model.Lecturers, error)
+	GetLecturerIDByUserID(userID string) (string, error)
+	HasVerificationOverride(lecturerID string) (bool, error)
+	GrantVerificationOverride(lecturerID, grantedBy string) error
+	RevokeVerificationOverride(lecturerID string) error
+	SharesDepartmentWithAdvisor(lecturerID, studentID string) (bool, error)
+	GetDelegations(delegatorID string) ([]model.LecturerDelegation, error)
+	CreateDelegation(d *model.LecturerDelegation) error
+	DeleteDelegation(delegatorID, delegationID string) error
+	GetActiveDelegatorIDs(delegateID string, at time.Time) ([]string, error)
+}
+
 type LecturerRepository struct {
 	db *sql.DB
 }
@@ -59,6 +74,139 @@ func (r *LecturerRepository) GetLecturerIDByUserID(userID string) (string, error
 	return lecturerID, nil
 }
 
+// HasVerificationOverride reports whether the lecturer may verify outside their own advisees
+func (r *LecturerRepository) HasVerificationOverride(lecturerID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM lecturer_verification_overrides WHERE lecturer_id = $1)`
+	var exists bool
+	err := r.db.QueryRow(query, lecturerID).Scan(&exists)
+	return exists, err
+}
+
+func (r *LecturerRepository) GrantVerificationOverride(lecturerID, grantedBy string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO lecturer_verification_overrides (lecturer_id, granted_by, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (lecturer_id) DO NOTHING
+	`, lecturerID, grantedBy)
+	return err
+}
+
+func (r *LecturerRepository) RevokeVerificationOverride(lecturerID string) error {
+	result, err := r.db.Exec(`DELETE FROM lecturer_verification_overrides WHERE lecturer_id = $1`, lecturerID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SharesDepartmentWithAdvisor reports whether the lecturer is in the same department as the student's advisor
+func (r *LecturerRepository) SharesDepartmentWithAdvisor(lecturerID, studentID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM students s
+			JOIN lecturers adv ON s.advisor_id = adv.id
+			JOIN lecturers l ON l.department = adv.department
+			WHERE s.id = $1 AND l.id = $2
+		)
+	`
+	var exists bool
+	err := r.db.QueryRow(query, studentID, lecturerID).Scan(&exists)
+	return exists, err
+}
+
+func (r *LecturerRepository) GetDelegations(delegatorID string) ([]model.LecturerDelegation, error) {
+	rows, err := r.db.Query(`
+		SELECT d.id, d.delegator_id, d.delegate_id, u.full_name, d.start_date, d.end_date, d.created_by, d.created_at
+		FROM lecturer_delegations AS d
+		JOIN lecturers AS l ON d.delegate_id = l.id
+		JOIN users AS u ON l.user_id = u.id
+		WHERE d.delegator_id = $1
+		ORDER BY d.start_date DESC
+	`, delegatorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	delegations := []model.LecturerDelegation{}
+	for rows.Next() {
+		var d model.LecturerDelegation
+		err := rows.Scan(
+			&d.ID, &d.DelegatorID, &d.DelegateID, &d.DelegateName,
+			&d.StartDate, &d.EndDate, &d.CreatedBy, &d.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delegations = append(delegations, d)
+	}
+
+	return delegations, rows.Err()
+}
+
+func (r *LecturerRepository) CreateDelegation(d *model.LecturerDelegation) error {
+	return r.db.QueryRow(`
+		INSERT INTO lecturer_delegations (delegator_id, delegate_id, start_date, end_date, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`, d.DelegatorID, d.DelegateID, d.StartDate, d.EndDate, d.CreatedBy).Scan(&d.ID, &d.CreatedAt)
+}
+
+func (r *LecturerRepository) DeleteDelegation(delegatorID, delegationID string) error {
+	result, err := r.db.Exec(`
+		DELETE FROM lecturer_delegations WHERE id = $1 AND delegator_id = $2
+	`, delegationID, delegatorID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetActiveDelegatorIDs returns the advisors whose duties are delegated to the lecturer on the given day
+func (r *LecturerRepository) GetActiveDelegatorIDs(delegateID string, at time.Time) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT DISTINCT delegator_id
+		FROM lecturer_delegations
+		WHERE delegate_id = $1 AND $2::date BETWEEN start_date AND end_date
+	`, delegateID, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var delegatorIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		delegatorIDs = append(delegatorIDs, id)
+	}
+
+	return delegatorIDs, rows.Err()
+}
+
 // Legacy function for backward compatibility
 func GetLecturersRepository(db *sql.DB) ([]model.Lecturers, error) {
 	repo := NewLecturerRepository(db)
@@ -5,6 +5,13 @@ import (
 	"student-report/app/model"
 )
 
+// IStudentRepository defines the student operations used by the verification authorizer
+type IStudentRepository interface {
+	GetStudentsRepository() ([]model.Students, error)
+	GetStudentByUserID(userID string) (*model.Students, error)
+	GetStudentByAdvisorID(advisorID string) ([]model.Students, error)
+}
+
 type StudentRepository struct {
 	db *sql.DB
 }
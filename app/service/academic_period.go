@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report/app/model"
+)
+
+// Period codes are the starting year of the academic year and the semester, e.g. 2025-GANJIL
+var academicPeriodCodePattern = regexp.MustCompile(`^([0-9]{4})-(GANJIL|GENAP|PENDEK)$`)
+
+var semesterNames = map[string]string{
+	"GANJIL": "Semester Ganjil",
+	"GENAP":  "Semester Genap",
+	"PENDEK": "Semester Pendek",
+}
+
+// List academic periods, oldest first
+func (s *AchievementService) GetAcademicPeriods() ([]model.AcademicPeriod, error) {
+	return s.repo.GetAcademicPeriods()
+}
+
+// Create or replace an academic period (Admin). Periods may not overlap, so every date
+// belongs to at most one period.
+func (s *AchievementService) SaveAcademicPeriod(code string, req model.SaveAcademicPeriodRequest) (*model.AcademicPeriod, error) {
+	var fields []model.FieldError
+	add := func(field, message string) {
+		fields = append(fields, model.FieldError{Field: field, Message: message})
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	match := academicPeriodCodePattern.FindStringSubmatch(code)
+	if match == nil {
+		add("code", "must look like 2025-GANJIL (year followed by GANJIL, GENAP or PENDEK)")
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		add("startDate", "must be a date in YYYY-MM-DD format")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		add("endDate", "must be a date in YYYY-MM-DD format")
+	}
+	if len(fields) == 0 && endDate.Before(startDate) {
+		add("endDate", "must not be before startDate")
+	}
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	period := &model.AcademicPeriod{
+		Code:         code,
+		Name:         strings.TrimSpace(req.Name),
+		AcademicYear: fmt.Sprintf("%d/%d", year, year+1),
+		Semester:     match[2],
+		StartDate:    startDate,
+		EndDate:      endDate,
+	}
+	if period.Name == "" {
+		period.Name = semesterNames[period.Semester] + " " + period.AcademicYear
+	}
+
+	periods, err := s.repo.GetAcademicPeriods()
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range periods {
+		if other.Code != code && !startDate.After(other.EndDate) && !other.StartDate.After(endDate) {
+			return nil, &ValidationError{Fields: []model.FieldError{
+				{Field: "startDate", Message: "overlaps academic period " + other.Code},
+			}}
+		}
+	}
+
+	if err := s.repo.SaveAcademicPeriod(period); err != nil {
+		return nil, fmt.Errorf("failed to save academic period: %w", err)
+	}
+	return period, nil
+}
+
+// Delete an academic period (Admin); achievements are bucketed by date, so none refer to it
+func (s *AchievementService) DeleteAcademicPeriod(code string) error {
+	period, err := s.findAcademicPeriod(code)
+	if err != nil {
+		return err
+	}
+	if period == nil {
+		return fmt.Errorf("%w: academic period %s does not exist", ErrAchievementNotFound, code)
+	}
+	return s.repo.DeleteAcademicPeriod(period.Code)
+}
+
+// ResolveAcademicPeriod looks up the period named by a period query parameter; empty means no period
+func (s *AchievementService) ResolveAcademicPeriod(code string) (*model.AcademicPeriod, error) {
+	if code == "" {
+		return nil, nil
+	}
+	period, err := s.findAcademicPeriod(code)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, &ValidationError{Fields: []model.FieldError{
+			{Field: "period", Message: "academic period " + code + " does not exist"},
+		}}
+	}
+	return period, nil
+}
+
+// findAcademicPeriod returns the period with the code (case-insensitive), or nil
+func (s *AchievementService) findAcademicPeriod(code string) (*model.AcademicPeriod, error) {
+	periods, err := s.repo.GetAcademicPeriods()
+	if err != nil {
+		return nil, err
+	}
+	for i := range periods {
+		if strings.EqualFold(periods[i].Code, code) {
+			return &periods[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"student-report/app/model"
+	"student-report/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// List Academic Periods
+func GetAcademicPeriodsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	periods, err := achievementService.GetAcademicPeriods()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil periode akademik",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    periods,
+		"success": true,
+	})
+}
+
+// Create Academic Period (Admin)
+func CreateAcademicPeriodService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	var req struct {
+		Code string `json:"code"`
+		model.SaveAcademicPeriodRequest
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	existing, err := achievementService.findAcademicPeriod(req.Code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal membuat periode akademik",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	if existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Periode akademik sudah ada",
+			"success": false,
+		})
+	}
+
+	return saveAcademicPeriod(c, achievementService, req.Code, req.SaveAcademicPeriodRequest, fiber.StatusCreated, "Periode akademik berhasil dibuat")
+}
+
+// Update Academic Period (Admin)
+func UpdateAcademicPeriodService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	code := c.Params("code")
+
+	var req model.SaveAcademicPeriodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	existing, err := achievementService.findAcademicPeriod(code)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal memperbarui periode akademik",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	if existing == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Periode akademik tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	return saveAcademicPeriod(c, achievementService, existing.Code, req, fiber.StatusOK, "Periode akademik berhasil diperbarui")
+}
+
+// Delete Academic Period (Admin)
+func DeleteAcademicPeriodService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	if err := achievementService.DeleteAcademicPeriod(c.Params("code")); err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal menghapus periode akademik",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Periode akademik berhasil dihapus",
+		"success": true,
+	})
+}
+
+func saveAcademicPeriod(c *fiber.Ctx, achievementService *AchievementService, code string, req model.SaveAcademicPeriodRequest, status int, message string) error {
+	period, err := achievementService.SaveAcademicPeriod(code, req)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Data periode akademik tidak valid",
+				"errors":  validationErr.Fields,
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal menyimpan periode akademik",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"data":    period,
+		"message": message,
+		"success": true,
+	})
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report/app/model"
+	"student-report/app/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const defaultAttachmentMaxBytes = 10 << 20
+
+// allowedAttachmentTypes are the sniffed content types accepted as evidence
+var allowedAttachmentTypes = []string{"application/pdf", "image/jpeg", "image/png", "image/webp"}
+
+// AttachmentMaxBytes is the upload size limit, configurable with ATTACHMENT_MAX_BYTES
+func AttachmentMaxBytes() int64 {
+	if size, err := strconv.ParseInt(os.Getenv("ATTACHMENT_MAX_BYTES"), 10, 64); err == nil && size > 0 {
+		return size
+	}
+	return defaultAttachmentMaxBytes
+}
+
+// SetAttachmentStorage replaces the storage backend for uploaded files
+func (s *AchievementService) SetAttachmentStorage(st storage.Storage) {
+	s.storage = st
+}
+
+// SetAttachmentMaxBytes overrides the upload size limit
+func (s *AchievementService) SetAttachmentMaxBytes(size int64) {
+	if size > 0 {
+		s.maxFileBytes = size
+	}
+}
+
+// StoreAttachment uploads a file to the storage backend and attaches it to the student's achievement.
+// The content type is sniffed from the bytes rather than trusted from the client.
+func (s *AchievementService) StoreAttachment(ctx context.Context, refID, studentID, fileName string, content io.Reader) (*model.Attachment, error) {
+	ref, _, err := s.editableAchievement(ctx, refID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.storeFile(ctx, ref, primitive.NewObjectID().Hex(), fileName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddAttachmentMongo(ctx, ref.MongoAchievementID, *attachment); err != nil {
+		s.storage.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+
+	if err := s.snapshotRevision(ctx, ref.MongoAchievementID); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// ReplaceAttachment uploads a new version of an attachment under the same ID while the
+// achievement can still be edited; the previous blob is removed once the swap is saved
+func (s *AchievementService) ReplaceAttachment(ctx context.Context, refID, studentID, attachmentID, fileName string, content io.Reader) (*model.Attachment, error) {
+	ref, current, err := s.editableAttachment(ctx, refID, studentID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.storeFile(ctx, ref, attachmentID, fileName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceAttachmentMongo(ctx, ref.MongoAchievementID, *attachment); err != nil {
+		s.storage.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	s.deleteBlob(ctx, current.StorageKey)
+
+	if err := s.snapshotRevision(ctx, ref.MongoAchievementID); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment removes an attachment and its stored file while the achievement can still be edited
+func (s *AchievementService) DeleteAttachment(ctx context.Context, refID, studentID, attachmentID string) error {
+	ref, current, err := s.editableAttachment(ctx, refID, studentID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveAttachmentMongo(ctx, ref.MongoAchievementID, attachmentID); err != nil {
+		return err
+	}
+	s.deleteBlob(ctx, current.StorageKey)
+
+	return s.snapshotRevision(ctx, ref.MongoAchievementID)
+}
+
+// editableAttachment loads an attachment the student may change (see editableAchievement)
+func (s *AchievementService) editableAttachment(ctx context.Context, refID, studentID, attachmentID string) (*model.AchievementReference, *model.Attachment, error) {
+	ref, achievement, err := s.editableAchievement(ctx, refID, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment := findAttachment(achievement.Attachments, attachmentID)
+	if attachment == nil {
+		return nil, nil, fmt.Errorf("%w: attachment %s not found", ErrAchievementNotFound, attachmentID)
+	}
+	current := *attachment
+	return ref, &current, nil
+}
+
+// editableAchievement loads an achievement whose attachments the student may change: their own
+// (the leader's, for a team) while it is in draft or rejected status, as for UpdateAchievement
+func (s *AchievementService) editableAchievement(ctx context.Context, refID, studentID string) (*model.AchievementReference, *model.Achievement, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check ownership
+	if ref.StudentID != studentID {
+		return nil, nil, fmt.Errorf("%w: you can only change attachments of your own achievements", ErrUnauthorized)
+	}
+
+	achievement, err := s.requireTeamLeader(ctx, ref, studentID, "change attachments of")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check status - only draft or rejected (revision) can be changed
+	editable := s.stateMachine.IsEditable(ref.Status)
+	if achievement.IsTeam() {
+		if editable, err = s.teamEditable(ref); err != nil {
+			return nil, nil, err
+		}
+	}
+	if !editable {
+		return nil, nil, fmt.Errorf("%w: can only change attachments in draft or rejected status", ErrInvalidTransition)
+	}
+
+	return ref, achievement, nil
+}
+
+// deleteBlob removes a stored file; the attachment is already gone from the achievement,
+// so a failure only leaves an orphaned file behind and is logged rather than returned
+func (s *AchievementService) deleteBlob(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		log.Printf("failed to delete attachment blob %s: %v", key, err)
+	}
+}
+
+// OpenAttachment returns an attachment and, for uploaded files, its content. allowedStudentIDs
+// limits whose achievements the caller may read; nil means no restriction. Legacy link-only
+// attachments come back with a nil reader.
+func (s *AchievementService) OpenAttachment(ctx context.Context, refID, attachmentID string, allowedStudentIDs []string) (*model.Attachment, io.ReadCloser, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("achievement not found in MongoDB: %w", err)
+	}
+
+	// Every team member (and their advisor) may read the shared evidence
+	if allowedStudentIDs != nil {
+		allowed := containsValue(allowedStudentIDs, ref.StudentID)
+		for _, member := range achievement.TeamMembers {
+			allowed = allowed || containsValue(allowedStudentIDs, member.StudentID)
+		}
+		if !allowed {
+			return nil, nil, fmt.Errorf("%w: you cannot access attachments of this achievement", ErrUnauthorized)
+		}
+	}
+
+	attachment := findAttachment(achievement.Attachments, attachmentID)
+	if attachment == nil {
+		return nil, nil, fmt.Errorf("%w: attachment %s not found", ErrAchievementNotFound, attachmentID)
+	}
+	if attachment.StorageKey == "" {
+		return attachment, nil, nil
+	}
+
+	content, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+	return attachment, content, nil
+}
+
+// storeFile checks size and content type, then writes the file under the achievement's key prefix
+func (s *AchievementService) storeFile(ctx context.Context, ref *model.AchievementReference, attachmentID, fileName string, content io.Reader) (*model.Attachment, error) {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if fileName == "." || fileName == "/" {
+		return nil, &ValidationError{Fields: []model.FieldError{{Field: "file", Message: "file name is required"}}}
+	}
+
+	// Read one byte past the limit to detect oversized uploads
+	data, err := io.ReadAll(io.LimitReader(content, s.maxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, &ValidationError{Fields: []model.FieldError{{Field: "file", Message: "file is empty"}}}
+	}
+	if int64(len(data)) > s.maxFileBytes {
+		return nil, &ValidationError{Fields: []model.FieldError{
+			{Field: "file", Message: fmt.Sprintf("must not be larger than %d bytes", s.maxFileBytes)},
+		}}
+	}
+
+	fileType := strings.SplitN(http.DetectContentType(data), ";", 2)[0]
+	if !containsValue(allowedAttachmentTypes, fileType) {
+		return nil, &ValidationError{Fields: []model.FieldError{
+			{Field: "file", Message: fileType + " is not allowed; upload one of " + strings.Join(allowedAttachmentTypes, ", ")},
+		}}
+	}
+
+	sum := sha256.Sum256(data)
+	// Every version gets its own key so a replacement never overwrites the file it replaces
+	key := "achievements/" + ref.MongoAchievementID + "/" + attachmentID + "/" + primitive.NewObjectID().Hex()
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	return &model.Attachment{
+		ID:         attachmentID,
+		FileName:   fileName,
+		FileURL:    "/achievements/" + ref.MongoAchievementID + "/attachments/" + attachmentID,
+		FileType:   fileType,
+		Size:       int64(len(data)),
+		SHA256:     hex.EncodeToString(sum[:]),
+		StorageKey: key,
+		UploadedAt: time.Now(),
+	}, nil
+}
+
+func findAttachment(attachments []model.Attachment, attachmentID string) *model.Attachment {
+	for i := range attachments {
+		if attachments[i].ID == attachmentID {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mime"
+
+	"student-report/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Upload Attachment - Mahasiswa (multipart form field "file")
+func UploadAttachmentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	// Get student record
+	studentRepo := repository.NewStudentRepository(db)
+	student, err := studentRepo.GetStudentByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "File wajib diupload sebagai multipart field 'file'",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	if fileHeader.Size > achievementService.maxFileBytes {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"message": fmt.Sprintf("Ukuran file maksimal %d bytes", achievementService.maxFileBytes),
+			"success": false,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Gagal membaca file",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	attachment, err := achievementService.StoreAttachment(ctx, achievementID, student.ID, fileHeader.Filename, file)
+	if err != nil {
+		return attachmentErrorResponse(c, err, "Gagal upload attachment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":    attachment,
+		"message": "Attachment berhasil diupload",
+		"success": true,
+	})
+}
+
+// Replace Attachment - Mahasiswa (draft or rejected, multipart form field "file")
+func ReplaceAttachmentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	attachmentID := c.Params("attachmentId")
+	userID := c.Locals("user_id").(string)
+
+	// Get student record
+	studentRepo := repository.NewStudentRepository(db)
+	student, err := studentRepo.GetStudentByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "File wajib diupload sebagai multipart field 'file'",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	if fileHeader.Size > achievementService.maxFileBytes {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"message": fmt.Sprintf("Ukuran file maksimal %d bytes", achievementService.maxFileBytes),
+			"success": false,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Gagal membaca file",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	attachment, err := achievementService.ReplaceAttachment(ctx, achievementID, student.ID, attachmentID, fileHeader.Filename, file)
+	if err != nil {
+		return attachmentErrorResponse(c, err, "Gagal mengganti attachment")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    attachment,
+		"message": "Attachment berhasil diganti",
+		"success": true,
+	})
+}
+
+// Delete Attachment - Mahasiswa (draft or rejected)
+func DeleteAttachmentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	attachmentID := c.Params("attachmentId")
+	userID := c.Locals("user_id").(string)
+
+	// Get student record
+	studentRepo := repository.NewStudentRepository(db)
+	student, err := studentRepo.GetStudentByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ctx := context.Background()
+	if err := achievementService.DeleteAttachment(ctx, achievementID, student.ID, attachmentID); err != nil {
+		return attachmentErrorResponse(c, err, "Gagal menghapus attachment")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attachment berhasil dihapus",
+		"success": true,
+	})
+}
+
+// Download Attachment - owner, advisor (or delegate), approvers and admin
+func DownloadAttachmentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	attachmentID := c.Params("attachmentId")
+
+	allowedStudentIDs, err := achievementReaderScope(c, db)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Tidak dapat menentukan akses attachment",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ctx := context.Background()
+	attachment, content, err := achievementService.OpenAttachment(ctx, achievementID, attachmentID, allowedStudentIDs)
+	if err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal mengambil attachment",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	// Attachments recorded before uploads existed only carry an external link
+	if content == nil {
+		return c.Redirect(attachment.FileURL, fiber.StatusFound)
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.FileType)
+	// RFC 6266: non-ASCII names go in filename* (RFC 2231) so they download intact
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.Set(fiber.HeaderContentDisposition, disposition)
+	c.Set("X-Checksum-SHA256", attachment.SHA256)
+	return c.SendStream(content, int(attachment.Size))
+}
+
+// achievementReaderScope limits students to their own achievements and plain advisors to their
+// (delegated) advisees; admins and department/faculty approvers are not restricted (nil).
+// Used for attachment downloads and expiry reminders.
+func achievementReaderScope(c *fiber.Ctx, db *sql.DB) ([]string, error) {
+	role, _ := c.Locals("role").(string)
+	userID := c.Locals("user_id").(string)
+
+	switch role {
+	case RoleAdmin:
+		return nil, nil
+	case RoleStudent:
+		student, err := repository.NewStudentRepository(db).GetStudentByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return []string{student.ID}, nil
+	}
+
+	permissions, _ := c.Locals("permissions").([]string)
+	if hasPermission(permissions, "achievement:approve_department") || hasPermission(permissions, "achievement:approve_faculty") {
+		return nil, nil
+	}
+
+	lecturerRepo := repository.NewLecturerRepository(db)
+	lecturerID, err := lecturerRepo.GetLecturerIDByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return adviseeIDsWithDelegations(db, lecturerRepo, lecturerID)
+}
+
+// attachmentErrorResponse reports validation failures per field and other errors by status
+func attachmentErrorResponse(c *fiber.Ctx, err error, message string) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "File attachment tidak valid",
+			"errors":  validationErr.Fields,
+			"success": false,
+		})
+	}
+	return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+		"message": message,
+		"error":   err.Error(),
+		"success": false,
+	})
+}
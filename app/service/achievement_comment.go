@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"student-report/app/model"
+)
+
+const (
+	CommentKindComment       = "comment"
+	CommentKindChangeRequest = "change_request"
+)
+
+// Get the review discussion of an achievement as threads (root comments with their replies).
+// Reviewers see only the discussions they may take part in.
+func (s *AchievementService) GetComments(ctx context.Context, refID, viewerID, viewerRole, studentID string) ([]model.AchievementComment, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
+	}
+	if viewerRole == RoleStudent && ref.StudentID != studentID {
+		return nil, fmt.Errorf("%w: you can only view comments on your own achievements", ErrUnauthorized)
+	}
+	if viewerRole != RoleStudent {
+		if err := s.authorizeReviewer(ctx, ref, viewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	comments, err := s.repo.GetComments(ref.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCommentThreads(comments), nil
+}
+
+// Add a comment or a change request to an achievement's review discussion.
+// studentID is the caller's student profile and is only checked for students.
+func (s *AchievementService) AddComment(ctx context.Context, refID, authorID, authorRole, studentID string, req model.CreateCommentRequest) (*model.AchievementComment, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
+	}
+	if authorRole == RoleStudent && ref.StudentID != studentID {
+		return nil, fmt.Errorf("%w: you can only comment on your own achievements", ErrUnauthorized)
+	}
+	if authorRole != RoleStudent {
+		if err := s.authorizeReviewer(ctx, ref, authorID); err != nil {
+			return nil, err
+		}
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("comment body is required")
+	}
+
+	kind := req.Kind
+	if kind == "" {
+		kind = CommentKindComment
+	}
+	switch kind {
+	case CommentKindComment:
+	case CommentKindChangeRequest:
+		// Asking for changes sends the achievement back to draft instead of rejecting it
+		if authorRole == RoleStudent {
+			return nil, fmt.Errorf("%w: only reviewers can request changes", ErrUnauthorized)
+		}
+		if !IsPendingReview(ref.Status) {
+			return nil, fmt.Errorf("%w: changes can only be requested while the achievement is under review", ErrInvalidTransition)
+		}
+	default:
+		return nil, fmt.Errorf("invalid comment kind: %s", kind)
+	}
+
+	comment := &model.AchievementComment{
+		AchievementReferenceID: ref.ID,
+		AuthorID:               authorID,
+		AuthorRole:             authorRole,
+		Kind:                   kind,
+		Body:                   body,
+	}
+
+	if req.ParentID != nil && *req.ParentID != "" {
+		parent, err := s.repo.GetComment(*req.ParentID)
+		if err != nil || parent.AchievementReferenceID != ref.ID {
+			return nil, fmt.Errorf("%w: parent comment not found", ErrAchievementNotFound)
+		}
+		// Threads are one level deep; replying to a reply attaches to its root
+		rootID := parent.ID
+		if parent.ParentID != nil {
+			rootID = *parent.ParentID
+		}
+		comment.ParentID = &rootID
+	}
+
+	// The change request's reason is saved in the same transaction that reopens the achievement
+	if kind == CommentKindChangeRequest {
+		if err := s.transition(ref, StatusDraft, authorID, authorRole, &body, func(change *model.AchievementTransition) {
+			clearSubmitted(change)
+			change.Comment = comment
+		}); err != nil {
+			return nil, err
+		}
+		return comment, nil
+	}
+
+	if err := s.repo.CreateComment(comment); err != nil {
+		return nil, fmt.Errorf("failed to save comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// Mark a comment thread as resolved
+func (s *AchievementService) ResolveComment(ctx context.Context, refID, commentID, authorID, authorRole, studentID string) error {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return err
+	}
+	if authorRole == RoleStudent && ref.StudentID != studentID {
+		return fmt.Errorf("%w: you can only resolve comments on your own achievements", ErrUnauthorized)
+	}
+	if authorRole != RoleStudent {
+		if err := s.authorizeReviewer(ctx, ref, authorID); err != nil {
+			return err
+		}
+	}
+
+	comment, err := s.repo.GetComment(commentID)
+	if err != nil || comment.AchievementReferenceID != ref.ID {
+		return fmt.Errorf("%w: comment not found", ErrAchievementNotFound)
+	}
+	if comment.ParentID != nil {
+		commentID = *comment.ParentID
+	}
+
+	return s.repo.ResolveComment(commentID)
+}
+
+// authorizeReviewer lets the student's advisor (or a delegate) and the approver of the current
+// stage take part in an achievement's review discussion
+func (s *AchievementService) authorizeReviewer(ctx context.Context, ref *model.AchievementReference, reviewerID string) error {
+	if s.verifierAuth == nil {
+		return nil
+	}
+
+	achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAchievementNotFound, err)
+	}
+	stages, err := s.approvalStages(achievement)
+	if err != nil {
+		return err
+	}
+
+	advisorErr := s.verifierAuth.AuthorizeStage(stages[0], reviewerID, ref.StudentID)
+	if advisorErr == nil || !IsPendingReview(ref.Status) {
+		return advisorErr
+	}
+	stage, err := stageAfter(stages, ref.Status)
+	if err != nil {
+		return err
+	}
+	return s.verifierAuth.AuthorizeStage(*stage, reviewerID, ref.StudentID)
+}
+
+// withCommentCounts fills the comment counters of responses built from the given references (same order)
+func (s *AchievementService) withCommentCounts(results []model.AchievementResponse, refIDs []string) {
+	counts, err := s.repo.GetCommentCounts(refIDs)
+	if err != nil {
+		return
+	}
+	for i := range results {
+		if i < len(refIDs) {
+			results[i].Comments = counts[refIDs[i]]
+		}
+	}
+}
+
+func buildCommentThreads(comments []model.AchievementComment) []model.AchievementComment {
+	threads := []model.AchievementComment{}
+	index := make(map[string]int)
+
+	for _, c := range comments {
+		if c.ParentID == nil {
+			index[c.ID] = len(threads)
+			threads = append(threads, c)
+		}
+	}
+	for _, c := range comments {
+		if c.ParentID == nil {
+			continue
+		}
+		if i, ok := index[*c.ParentID]; ok {
+			threads[i].Replies = append(threads[i].Replies, c)
+		}
+	}
+
+	return threads
+}
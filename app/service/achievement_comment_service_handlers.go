@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"student-report/app/model"
+	"student-report/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Get Achievement Comment Threads
+func GetAchievementCommentsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+
+	role, studentID, err := commentCaller(c, db)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := newVerifierAchievementService(c, db, achievementRepo)
+
+	userID := c.Locals("user_id").(string)
+	comments, err := achievementService.GetComments(context.Background(), achievementID, userID, role, studentID)
+	if err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal mengambil komentar achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    comments,
+		"total":   len(comments),
+		"success": true,
+	})
+}
+
+// Add Achievement Comment or Change Request
+func AddAchievementCommentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	var req model.CreateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	role, studentID, err := commentCaller(c, db)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := newVerifierAchievementService(c, db, achievementRepo)
+
+	ctx := context.Background()
+	comment, err := achievementService.AddComment(ctx, achievementID, userID, role, studentID, req)
+	if err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal menambahkan komentar",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":    comment,
+		"message": "Komentar berhasil ditambahkan",
+		"success": true,
+	})
+}
+
+// Resolve Achievement Comment Thread
+func ResolveAchievementCommentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	commentID := c.Params("commentId")
+
+	role, studentID, err := commentCaller(c, db)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := newVerifierAchievementService(c, db, achievementRepo)
+
+	userID := c.Locals("user_id").(string)
+	if err := achievementService.ResolveComment(context.Background(), achievementID, commentID, userID, role, studentID); err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal menyelesaikan diskusi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Diskusi ditandai selesai",
+		"success": true,
+	})
+}
+
+// commentCaller returns the caller's role and, for students, their student profile ID
+func commentCaller(c *fiber.Ctx, db *sql.DB) (string, string, error) {
+	role, _ := c.Locals("role").(string)
+	if role != RoleStudent {
+		return role, "", nil
+	}
+
+	userID := c.Locals("user_id").(string)
+	student, err := repository.NewStudentRepository(db).GetStudentByUserID(userID)
+	if err != nil {
+		return role, "", err
+	}
+	return role, student.ID, nil
+}
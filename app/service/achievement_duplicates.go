@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"student-report/app/model"
+)
+
+// Reasons recorded on a DuplicateMatch
+const (
+	DuplicateReasonTitle               = "title"
+	DuplicateReasonCompetition         = "competition"
+	DuplicateReasonCertificationNumber = "certificationNumber"
+	DuplicateReasonISSN                = "issn"
+)
+
+// normalizeText lowercases and reduces punctuation and repeated spaces to single spaces,
+// so "Gemastik XV - Juara 1" and "gemastik xv juara 1" compare equal
+func normalizeText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// titleKey is the normalized title, computed for achievements saved before it was stored
+func titleKey(achievement *model.Achievement) string {
+	if achievement.NormalizedTitle != "" {
+		return achievement.NormalizedTitle
+	}
+	return normalizeText(achievement.Title)
+}
+
+// duplicateReasons explains why two achievements look like the same accomplishment
+func duplicateReasons(a, b *model.Achievement) []string {
+	var reasons []string
+	if title := titleKey(a); title != "" && title == titleKey(b) {
+		reasons = append(reasons, DuplicateReasonTitle)
+	}
+
+	da, db := a.Details, b.Details
+	if da.CompetitionName != "" && da.EventDate != "" && da.EventDate == db.EventDate &&
+		normalizeText(da.CompetitionName) == normalizeText(db.CompetitionName) {
+		reasons = append(reasons, DuplicateReasonCompetition)
+	}
+	if da.CertificationNumber != "" &&
+		normalizeText(da.CertificationNumber) == normalizeText(db.CertificationNumber) {
+		reasons = append(reasons, DuplicateReasonCertificationNumber)
+	}
+	// An ISSN identifies a journal, not a paper, so the paper title has to match too
+	if da.ISSN != "" && strings.EqualFold(da.ISSN, db.ISSN) && da.PublicationTitle != "" &&
+		normalizeText(da.PublicationTitle) == normalizeText(db.PublicationTitle) {
+		reasons = append(reasons, DuplicateReasonISSN)
+	}
+	return reasons
+}
+
+// flagDuplicates searches for likely duplicates of an achievement and stores them on it. Achievements
+// it matches now or matched before are refreshed too, so the other copy is flagged (or cleared) as well.
+func (s *AchievementService) flagDuplicates(ctx context.Context, mongoID string) error {
+	previous, err := s.duplicateIDs(ctx, mongoID)
+	if err != nil {
+		return err
+	}
+
+	matches, others, err := s.findDuplicates(ctx, mongoID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetPossibleDuplicatesMongo(ctx, mongoID, matches); err != nil {
+		return fmt.Errorf("failed to flag duplicates: %w", err)
+	}
+
+	for _, id := range previous {
+		if !containsValue(others, id) {
+			others = append(others, id)
+		}
+	}
+	return s.refreshDuplicates(ctx, others)
+}
+
+// refreshDuplicates recomputes the stored matches of the given achievements
+func (s *AchievementService) refreshDuplicates(ctx context.Context, mongoIDs []string) error {
+	for _, id := range mongoIDs {
+		matches, _, err := s.findDuplicates(ctx, id)
+		if err != nil {
+			// A matched achievement deleted in the meantime has nothing left to flag
+			continue
+		}
+		if err := s.repo.SetPossibleDuplicatesMongo(ctx, id, matches); err != nil {
+			return fmt.Errorf("failed to flag duplicates: %w", err)
+		}
+	}
+	return nil
+}
+
+// duplicateIDs lists the achievements currently flagged as duplicates of an achievement
+func (s *AchievementService) duplicateIDs(ctx context.Context, mongoID string) ([]string, error) {
+	achievement, err := s.repo.GetAchievementMongo(ctx, mongoID)
+	if err != nil {
+		return nil, fmt.Errorf("achievement not found in MongoDB: %w", err)
+	}
+
+	ids := make([]string, 0, len(achievement.Duplicates))
+	for _, match := range achievement.Duplicates {
+		ids = append(ids, match.AchievementID)
+	}
+	return ids, nil
+}
+
+// findDuplicates returns the matches for an achievement and the IDs of the matched achievements
+func (s *AchievementService) findDuplicates(ctx context.Context, mongoID string) ([]model.DuplicateMatch, []string, error) {
+	achievement, err := s.repo.GetAchievementMongo(ctx, mongoID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("achievement not found in MongoDB: %w", err)
+	}
+
+	// A legacy achievement searches by its computed title key like any other
+	search := *achievement
+	search.NormalizedTitle = titleKey(achievement)
+	candidates, err := s.repo.FindDuplicateCandidatesMongo(ctx, &search)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search for duplicates: %w", err)
+	}
+
+	matches := []model.DuplicateMatch{}
+	var ids []string
+	for i := range candidates {
+		reasons := duplicateReasons(achievement, &candidates[i])
+		if len(reasons) == 0 {
+			continue
+		}
+		matches = append(matches, model.DuplicateMatch{
+			AchievementID: candidates[i].ID.Hex(),
+			StudentID:     candidates[i].StudentID,
+			Title:         candidates[i].Title,
+			Reasons:       reasons,
+		})
+		ids = append(ids, candidates[i].ID.Hex())
+	}
+	return matches, ids, nil
+}
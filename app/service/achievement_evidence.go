@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"student-report/app/model"
+)
+
+// ErrMissingEvidence marks a submission refused because required evidence is missing
+var ErrMissingEvidence = errors.New("missing evidence")
+
+// MissingEvidenceError lists every evidence requirement the achievement does not meet yet
+type MissingEvidenceError struct {
+	Missing []model.EvidenceRequirement
+}
+
+func (e *MissingEvidenceError) Error() string {
+	names := make([]string, 0, len(e.Missing))
+	for _, r := range e.Missing {
+		names = append(names, r.Name)
+	}
+	return ErrMissingEvidence.Error() + ": " + strings.Join(names, ", ")
+}
+
+func (e *MissingEvidenceError) Unwrap() error {
+	return ErrMissingEvidence
+}
+
+// checkEvidence verifies the achievement meets its type's evidence requirements before it is submitted
+func (s *AchievementService) checkEvidence(ctx context.Context, ref *model.AchievementReference) error {
+	achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return fmt.Errorf("achievement not found in MongoDB: %w", err)
+	}
+
+	definition, err := s.findAchievementType(achievement.AchievementType)
+	if err != nil || definition == nil {
+		return err
+	}
+
+	if missing := missingEvidence(definition.EvidenceRequirements, achievement); len(missing) > 0 {
+		return &MissingEvidenceError{Missing: missing}
+	}
+	return nil
+}
+
+// missingEvidence returns the requirements met by neither an attachment type nor a filled-in detail field
+func missingEvidence(requirements []model.EvidenceRequirement, achievement *model.Achievement) []model.EvidenceRequirement {
+	present := presentDetailFields(achievement.Details)
+
+	var missing []model.EvidenceRequirement
+	for _, requirement := range requirements {
+		met := false
+		for _, field := range requirement.DetailFields {
+			if present[field] {
+				met = true
+			}
+		}
+		for _, attachment := range achievement.Attachments {
+			if containsValue(requirement.FileTypes, attachment.FileType) {
+				met = true
+			}
+		}
+		if !met {
+			missing = append(missing, requirement)
+		}
+	}
+	return missing
+}
+
+// validateEvidenceRequirements checks an admin-supplied requirement list
+func validateEvidenceRequirements(requirements []model.EvidenceRequirement) []model.FieldError {
+	var fields []model.FieldError
+	known := presentDetailFields(model.AchievementDetails{})
+	names := map[string]bool{}
+
+	for i, requirement := range requirements {
+		field := fmt.Sprintf("evidenceRequirements[%d]", i)
+		if requirement.Name == "" {
+			fields = append(fields, model.FieldError{Field: field + ".name", Message: "is required"})
+		} else if names[requirement.Name] {
+			fields = append(fields, model.FieldError{Field: field + ".name", Message: "must be unique"})
+		}
+		names[requirement.Name] = true
+
+		if len(requirement.FileTypes) == 0 && len(requirement.DetailFields) == 0 {
+			fields = append(fields, model.FieldError{Field: field, Message: "needs at least one file type or detail field"})
+		}
+		for _, fileType := range requirement.FileTypes {
+			if !containsValue(allowedAttachmentTypes, fileType) {
+				fields = append(fields, model.FieldError{Field: field + ".fileTypes", Message: "must be one of " + strings.Join(allowedAttachmentTypes, ", ")})
+			}
+		}
+		for _, detailField := range requirement.DetailFields {
+			if _, ok := known[detailField]; !ok {
+				fields = append(fields, model.FieldError{Field: field + ".detailFields", Message: "unknown detail field " + detailField})
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report/app/model"
+)
+
+// How many achievements an export reads per query
+const exportPageSize = 500
+
+// AchievementExportColumns heads the rows written by ExportAchievements
+var AchievementExportColumns = []string{
+	"Reference ID", "Achievement ID", "Student ID", "Student Name", "NIM", "Team Role",
+	"Type", "Title", "Description", "Tags", "Status", "Points",
+	"Event Date", "Location", "Organizer", "Score",
+	"Competition Name", "Competition Level", "Rank", "Medal Type",
+	"Publication Type", "Publication Title", "Authors", "Publisher", "ISSN", "DOI",
+	"Organization Name", "Position", "Period Start", "Period End",
+	"Certification Name", "Issued By", "Certification Number", "Valid Until",
+	"Custom Fields", "Created At", "Submitted At", "Verified At", "Verifier ID", "Verifier Name", "Rejection Note",
+}
+
+// ExportAchievements writes one row per student reference of every achievement matching the
+// filter, ignoring its page and limit, so each team member appears on their own row.
+// Unlike the paged list the filter's status is applied, to the reference's status.
+func (s *AchievementService) ExportAchievements(ctx context.Context, filter model.AchievementFilter, write func(record []string) error) error {
+	filter.Limit = exportPageSize
+	students := make(map[string][2]string)
+
+	var read int64
+	for filter.Page = 1; ; filter.Page++ {
+		achievements, total, err := s.repo.GetAchievementsWithFilter(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		var rows []exportRow
+		var verifierIDs []string
+		for i := range achievements {
+			refs, err := s.exportReferences(&achievements[i], filter)
+			if err != nil {
+				return err
+			}
+			for j := range refs {
+				row := s.combineAchievementResponse(&achievements[i], &refs[j])
+				rows = append(rows, exportRow{response: row, studentID: refs[j].StudentID})
+				if row.VerifiedBy != nil && !containsValue(verifierIDs, *row.VerifiedBy) {
+					verifierIDs = append(verifierIDs, *row.VerifiedBy)
+				}
+			}
+		}
+
+		verifiers, err := s.repo.GetUserNames(verifierIDs)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			student, known := students[row.studentID]
+			if !known {
+				name, nim := s.repo.GetStudentInfo(row.studentID)
+				student = [2]string{name, nim}
+				students[row.studentID] = student
+			}
+			if err := write(exportRecord(row, student[0], student[1], verifiers)); err != nil {
+				return err
+			}
+		}
+
+		read += int64(len(achievements))
+		if len(achievements) == 0 || read >= total {
+			return nil
+		}
+	}
+}
+
+// exportRow is one reference to an achievement; the response's StudentID is the document's
+// creator, while studentID is the team member the reference belongs to
+type exportRow struct {
+	response  *model.AchievementResponse
+	studentID string
+}
+
+// exportReferences lists the references of the achievement that belong in the export
+func (s *AchievementService) exportReferences(achievement *model.Achievement, filter model.AchievementFilter) ([]model.AchievementReference, error) {
+	refs, err := s.repo.GetAchievementReferencesByMongoID(achievement.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []model.AchievementReference
+	for _, ref := range refs {
+		if ref.Status == StatusDeleted {
+			continue
+		}
+		if filter.StudentID != nil && ref.StudentID != *filter.StudentID {
+			continue
+		}
+		if filter.Status != nil && ref.Status != *filter.Status {
+			continue
+		}
+		matching = append(matching, ref)
+	}
+	return matching, nil
+}
+
+// exportRecord flattens an achievement response into AchievementExportColumns order
+func exportRecord(export exportRow, studentName, nim string, verifiers map[string]string) []string {
+	row, studentID := export.response, export.studentID
+	details := row.Details
+	teamRole := ""
+	for _, member := range row.TeamMembers {
+		if member.StudentID == studentID {
+			teamRole = member.Role
+		}
+	}
+
+	var periodStart, periodEnd string
+	if details.Period != nil {
+		periodStart, periodEnd = exportDate(&details.Period.Start), exportDate(&details.Period.End)
+	}
+	verifierID, verifierName := "", ""
+	if row.VerifiedBy != nil {
+		verifierID, verifierName = *row.VerifiedBy, verifiers[*row.VerifiedBy]
+	}
+	rejectionNote := ""
+	if row.RejectionNote != nil {
+		rejectionNote = *row.RejectionNote
+	}
+
+	return []string{
+		row.ReferenceID, row.ID.Hex(), studentID, exportText(studentName), exportText(nim), exportText(teamRole),
+		row.AchievementType, exportText(row.Title), exportText(row.Description), exportText(strings.Join(row.Tags, ", ")), row.Status, strconv.Itoa(row.Points),
+		exportText(details.EventDate), exportText(details.Location), exportText(details.Organizer), exportNumber(details.Score),
+		exportText(details.CompetitionName), exportText(details.CompetitionLevel), exportNumber(float64(details.Rank)), exportText(details.MedalType),
+		exportText(details.PublicationType), exportText(details.PublicationTitle), exportText(strings.Join(details.Authors, ", ")), exportText(details.Publisher), exportText(details.ISSN), exportText(details.DOI),
+		exportText(details.OrganizationName), exportText(details.Position), periodStart, periodEnd,
+		exportText(details.CertificationName), exportText(details.IssuedBy), exportText(details.CertificationNumber), exportDate(details.ValidUntil),
+		exportText(exportCustomFields(details.CustomFields)), exportTime(&row.CreatedAt), exportTime(row.SubmittedAt), exportTime(row.VerifiedAt),
+		verifierID, exportText(verifierName), exportText(rejectionNote),
+	}
+}
+
+// exportText keeps free text from being run as a spreadsheet formula: a cell starting with
+// =, +, -, @, tab or carriage return is prefixed with a quote (OWASP CSV injection)
+func exportText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// exportCustomFields writes custom fields as "key: value" pairs in key order
+func exportCustomFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s: %v", key, fields[key])
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// exportNumber leaves zero (unset) numbers empty
+func exportNumber(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func exportDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func exportTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"student-report/app/model"
+)
+
+// Get all content revisions of an achievement
+func (s *AchievementService) GetRevisions(ctx context.Context, refID string) ([]model.AchievementRevision, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAchievementRevisions(ctx, ref.MongoAchievementID)
+}
+
+// Compare two revisions of an achievement field by field
+func (s *AchievementService) DiffRevisions(ctx context.Context, refID string, fromRevision, toRevision int) (*model.AchievementRevisionDiff, error) {
+	revisions, err := s.GetRevisions(ctx, refID)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to *model.AchievementRevision
+	for i := range revisions {
+		if revisions[i].Revision == fromRevision {
+			from = &revisions[i]
+		}
+		if revisions[i].Revision == toRevision {
+			to = &revisions[i]
+		}
+	}
+	if from == nil || to == nil {
+		return nil, fmt.Errorf("%w: revision %d or %d does not exist", ErrAchievementNotFound, fromRevision, toRevision)
+	}
+
+	return diffRevisions(from, to), nil
+}
+
+// snapshotRevision stores the current MongoDB content as a new revision
+func (s *AchievementService) snapshotRevision(ctx context.Context, mongoID string) error {
+	achievement, err := s.repo.GetAchievementMongo(ctx, mongoID)
+	if err != nil {
+		return err
+	}
+
+	revision := &model.AchievementRevision{
+		AchievementID: mongoID,
+		Title:         achievement.Title,
+		Description:   achievement.Description,
+		Details:       achievement.Details,
+		Attachments:   append([]model.Attachment{}, achievement.Attachments...),
+		Tags:          append([]string{}, achievement.Tags...),
+		Points:        achievement.Points,
+	}
+
+	if err := s.repo.CreateAchievementRevision(ctx, revision); err != nil {
+		return fmt.Errorf("failed to record achievement revision: %w", err)
+	}
+	return nil
+}
+
+func diffRevisions(from, to *model.AchievementRevision) *model.AchievementRevisionDiff {
+	diff := &model.AchievementRevisionDiff{
+		AchievementID:      to.AchievementID,
+		FromRevision:       from.Revision,
+		ToRevision:         to.Revision,
+		Changes:            []model.FieldChange{},
+		TagsAdded:          []string{},
+		TagsRemoved:        []string{},
+		AttachmentsAdded:   []model.Attachment{},
+		AttachmentsRemoved: []model.Attachment{},
+	}
+
+	if from.Title != to.Title {
+		diff.Changes = append(diff.Changes, model.FieldChange{Field: "title", From: from.Title, To: to.Title})
+	}
+	if from.Description != to.Description {
+		diff.Changes = append(diff.Changes, model.FieldChange{Field: "description", From: from.Description, To: to.Description})
+	}
+	if from.Points != to.Points {
+		diff.Changes = append(diff.Changes, model.FieldChange{Field: "points", From: from.Points, To: to.Points})
+	}
+	diff.Changes = append(diff.Changes, diffDetails(from.Details, to.Details)...)
+
+	diff.TagsAdded, diff.TagsRemoved = diffStrings(from.Tags, to.Tags)
+
+	fromAttachments := make(map[string]model.Attachment)
+	for _, a := range from.Attachments {
+		fromAttachments[attachmentVersion(a)] = a
+	}
+	toAttachments := make(map[string]model.Attachment)
+	for _, a := range to.Attachments {
+		toAttachments[attachmentVersion(a)] = a
+		if _, exists := fromAttachments[attachmentVersion(a)]; !exists {
+			diff.AttachmentsAdded = append(diff.AttachmentsAdded, a)
+		}
+	}
+	for _, a := range from.Attachments {
+		if _, exists := toAttachments[attachmentVersion(a)]; !exists {
+			diff.AttachmentsRemoved = append(diff.AttachmentsRemoved, a)
+		}
+	}
+
+	return diff
+}
+
+// attachmentVersion identifies an attachment's content; a replaced file keeps its URL
+// but not its checksum, so it shows up as removed and added
+func attachmentVersion(a model.Attachment) string {
+	return a.FileURL + "#" + a.SHA256
+}
+
+// diffDetails compares AchievementDetails using the JSON field names the client sees
+func diffDetails(from, to model.AchievementDetails) []model.FieldChange {
+	fromMap := detailsToMap(from)
+	toMap := detailsToMap(to)
+
+	keys := make(map[string]bool)
+	for k := range fromMap {
+		keys[k] = true
+	}
+	for k := range toMap {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	changes := []model.FieldChange{}
+	for _, k := range sorted {
+		if !reflect.DeepEqual(fromMap[k], toMap[k]) {
+			changes = append(changes, model.FieldChange{Field: "details." + k, From: fromMap[k], To: toMap[k]})
+		}
+	}
+	return changes
+}
+
+func detailsToMap(details model.AchievementDetails) map[string]interface{} {
+	result := make(map[string]interface{})
+	data, err := json.Marshal(details)
+	if err != nil {
+		return result
+	}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+func diffStrings(from, to []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+
+	fromSet := make(map[string]bool)
+	for _, v := range from {
+		fromSet[v] = true
+	}
+	toSet := make(map[string]bool)
+	for _, v := range to {
+		toSet[v] = true
+		if !fromSet[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range from {
+		if !toSet[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
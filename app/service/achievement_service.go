@@ -4,32 +4,72 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"student-report/app/model"
 	"student-report/app/repository"
+	"student-report/app/storage"
+)
+
+var (
+	ErrAchievementNotFound = errors.New("achievement not found")
+	ErrUnauthorized        = errors.New("unauthorized")
 )
 
 type AchievementService struct {
-	repo repository.IAchievementRepository
+	repo          repository.IAchievementRepository
+	stateMachine  *AchievementStateMachine
+	verifierAuth  VerificationAuthorizer
+	slaDays       int
+	expiredWeight int
+	storage       storage.Storage
+	maxFileBytes  int64
 }
 
 func NewAchievementService(repo repository.IAchievementRepository) *AchievementService {
-	return &AchievementService{repo: repo}
+	return &AchievementService{
+		repo:          repo,
+		stateMachine:  NewAchievementStateMachine(),
+		slaDays:       verificationSLADays(),
+		expiredWeight: expiredCertificationWeight(),
+		storage:       storage.FromEnv(),
+		maxFileBytes:  AttachmentMaxBytes(),
+	}
+}
+
+// SetVerificationAuthorizer restricts who may verify or reject; nil allows any verifier
+func (s *AchievementService) SetVerificationAuthorizer(auth VerificationAuthorizer) {
+	s.verifierAuth = auth
 }
 
 // FR-003: Create Achievement (Student)
 func (s *AchievementService) CreateAchievement(ctx context.Context, studentID string, req model.CreateAchievementRequest) (*model.AchievementResponse, error) {
+	if err := s.validateAgainstTypes(req.AchievementType, req.Title, req.Details, true); err != nil {
+		return nil, err
+	}
+
+	team, err := s.normalizeTeam(studentID, req.TeamMembers)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create achievement in MongoDB
 	achievement := &model.Achievement{
 		StudentID:       studentID,
 		AchievementType: req.AchievementType,
 		Title:           req.Title,
+		NormalizedTitle: normalizeText(req.Title),
 		Description:     req.Description,
 		Details:         req.Details,
 		Tags:            req.Tags,
-		Points:          req.Points,
 		Attachments:     []model.Attachment{},
+		TeamMembers:     team,
+	}
+
+	// Points come from the rule table, never from the request
+	if err := s.applyPoints(achievement); err != nil {
+		return nil, err
 	}
 
 	mongoID, err := s.repo.CreateAchievementMongo(ctx, achievement)
@@ -37,10 +77,29 @@ func (s *AchievementService) CreateAchievement(ctx context.Context, studentID st
 		return nil, fmt.Errorf("failed to create achievement in MongoDB: %w", err)
 	}
 
-	// Create reference in PostgreSQL
-	refID, err := s.repo.CreateAchievementReference(studentID, mongoID)
+	// Create references in PostgreSQL: the creator's, and one for every other team member, verified
+	// by their own advisor. They are created together; if they cannot be, the document goes too.
+	studentIDs := []string{studentID}
+	for _, member := range team {
+		if member.StudentID != studentID {
+			studentIDs = append(studentIDs, member.StudentID)
+		}
+	}
+	refIDs, err := s.repo.CreateAchievementReferences(mongoID, studentIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create achievement reference: %w", err)
+		if cleanupErr := s.repo.DeleteAchievementMongo(ctx, mongoID); cleanupErr != nil {
+			log.Printf("failed to remove achievement %s after its references failed: %v", mongoID, cleanupErr)
+		}
+		return nil, fmt.Errorf("failed to create achievement references: %w", err)
+	}
+	refID := refIDs[0]
+
+	if err := s.snapshotRevision(ctx, mongoID); err != nil {
+		return nil, err
+	}
+
+	if err := s.flagDuplicates(ctx, mongoID); err != nil {
+		return nil, err
 	}
 
 	// Get the created achievement
@@ -59,9 +118,9 @@ func (s *AchievementService) CreateAchievement(ctx context.Context, studentID st
 
 // Get Achievement by ID
 func (s *AchievementService) GetAchievementByID(ctx context.Context, refID string) (*model.AchievementResponse, error) {
-	ref, err := s.repo.GetAchievementReference(refID)
+	ref, err := s.getReference(refID)
 	if err != nil {
-		return nil, fmt.Errorf("achievement reference not found: %w", err)
+		return nil, err
 	}
 
 	achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
@@ -69,33 +128,75 @@ func (s *AchievementService) GetAchievementByID(ctx context.Context, refID strin
 		return nil, fmt.Errorf("achievement not found in MongoDB: %w", err)
 	}
 
-	return s.combineAchievementResponse(achievement, ref), nil
+	// Attachments saved before IDs existed get one so they can be replaced or removed
+	if findAttachment(achievement.Attachments, "") != nil {
+		if err := s.repo.AssignAttachmentIDsMongo(ctx, ref.MongoAchievementID); err == nil {
+			if reloaded, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID); err == nil {
+				achievement = reloaded
+			}
+		}
+	}
+
+	response := s.combineAchievementResponse(achievement, ref)
+	if counts, err := s.repo.GetCommentCounts([]string{ref.ID}); err == nil {
+		response.Comments = counts[ref.ID]
+	}
+	if history, err := s.repo.GetStatusHistory(ref.ID); err == nil {
+		response.StatusHistory = history
+	}
+	if IsPendingReview(ref.Status) {
+		if stage, err := s.currentStage(achievement, ref.Status); err == nil {
+			response.CurrentStage = stage.Name
+		}
+	}
+
+	return response, nil
 }
 
-// Update Achievement (Student - only draft status)
+// Update Achievement (Student - draft or rejected status)
 func (s *AchievementService) UpdateAchievement(ctx context.Context, refID, studentID string, req model.UpdateAchievementRequest) (*model.AchievementResponse, error) {
-	ref, err := s.repo.GetAchievementReference(refID)
+	ref, err := s.getReference(refID)
 	if err != nil {
-		return nil, fmt.Errorf("achievement not found: %w", err)
+		return nil, err
 	}
 
 	// Check ownership
 	if ref.StudentID != studentID {
-		return nil, errors.New("unauthorized: you can only update your own achievements")
+		return nil, fmt.Errorf("%w: you can only update your own achievements", ErrUnauthorized)
 	}
 
-	// Check status - only draft can be updated
-	if ref.Status != "draft" {
-		return nil, errors.New("can only update achievements in draft status")
+	current, err := s.requireTeamLeader(ctx, ref, studentID, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status - only draft or rejected (revision) can be updated
+	editable := s.stateMachine.IsEditable(ref.Status)
+	if current.IsTeam() {
+		if editable, err = s.teamEditable(ref); err != nil {
+			return nil, err
+		}
+	}
+	if !editable {
+		return nil, fmt.Errorf("%w: can only update achievements in draft or rejected status", ErrInvalidTransition)
+	}
+
+	if err := s.validateAgainstTypes(current.AchievementType, req.Title, req.Details, false); err != nil {
+		return nil, err
 	}
 
 	// Update in MongoDB
 	update := &model.Achievement{
-		Title:       req.Title,
-		Description: req.Description,
-		Details:     req.Details,
-		Tags:        req.Tags,
-		Points:      req.Points,
+		AchievementType: current.AchievementType,
+		Title:           req.Title,
+		NormalizedTitle: normalizeText(req.Title),
+		Description:     req.Description,
+		Details:         req.Details,
+		Tags:            req.Tags,
+		TeamMembers:     current.TeamMembers,
+	}
+	if err := s.applyPoints(update); err != nil {
+		return nil, err
 	}
 
 	err = s.repo.UpdateAchievementMongo(ctx, ref.MongoAchievementID, update)
@@ -103,98 +204,307 @@ func (s *AchievementService) UpdateAchievement(ctx context.Context, refID, stude
 		return nil, fmt.Errorf("failed to update achievement: %w", err)
 	}
 
-	return s.GetAchievementByID(ctx, refID)
+	if err := s.snapshotRevision(ctx, ref.MongoAchievementID); err != nil {
+		return nil, err
+	}
+
+	if err := s.flagDuplicates(ctx, ref.MongoAchievementID); err != nil {
+		return nil, err
+	}
+
+	return s.GetAchievementByID(ctx, ref.ID)
 }
 
 // FR-004: Submit for Verification
 func (s *AchievementService) SubmitForVerification(ctx context.Context, refID, studentID string) (*model.AchievementResponse, error) {
-	ref, err := s.repo.GetAchievementReference(refID)
+	ref, err := s.getReference(refID)
 	if err != nil {
-		return nil, fmt.Errorf("achievement not found: %w", err)
+		return nil, err
 	}
 
 	// Check ownership
 	if ref.StudentID != studentID {
-		return nil, errors.New("unauthorized: you can only submit your own achievements")
+		return nil, fmt.Errorf("%w: you can only submit your own achievements", ErrUnauthorized)
+	}
+	achievement, err := s.requireTeamLeader(ctx, ref, studentID, "submit")
+	if err != nil {
+		return nil, err
 	}
 
-	// Check status - only draft can be submitted
-	if ref.Status != "draft" {
-		return nil, errors.New("can only submit achievements in draft status")
+	// Rejected achievements go through ResubmitAchievement so the rejection note is cleared
+	if ref.Status != StatusDraft {
+		return nil, fmt.Errorf("%w: can only submit achievements in draft status", ErrInvalidTransition)
 	}
 
-	// Update status to submitted
-	now := time.Now()
-	err = s.repo.UpdateAchievementStatus(refID, "submitted", &now)
+	// The type's rules may have changed since the achievement was last edited
+	if err := s.validateAgainstTypes(achievement.AchievementType, achievement.Title, achievement.Details, false); err != nil {
+		return nil, err
+	}
+	if err := s.checkEvidence(ctx, ref); err != nil {
+		return nil, err
+	}
+	if err := s.transition(ref, StatusSubmitted, studentID, RoleStudent, nil, markSubmitted); err != nil {
+		return nil, err
+	}
+
+	// The rest of the team goes to their own advisors for verification
+	_, err = s.moveTeam(ref, StatusDraft, StatusSubmitted, studentID, nil, markSubmitted)
 	if err != nil {
-		return nil, fmt.Errorf("failed to submit achievement: %w", err)
+		return nil, err
+	}
+
+	// Check again at submit time: a teammate may have logged the same achievement since
+	if err := s.flagDuplicates(ctx, ref.MongoAchievementID); err != nil {
+		return nil, err
+	}
+
+	return s.GetAchievementByID(ctx, ref.ID)
+}
+
+// Resubmit a revised achievement after rejection (Student)
+func (s *AchievementService) ResubmitAchievement(ctx context.Context, refID, studentID string) (*model.AchievementResponse, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check ownership
+	if ref.StudentID != studentID {
+		return nil, fmt.Errorf("%w: you can only resubmit your own achievements", ErrUnauthorized)
+	}
+	achievement, err := s.requireTeamLeader(ctx, ref, studentID, "resubmit")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateAgainstTypes(achievement.AchievementType, achievement.Title, achievement.Details, false); err != nil {
+		return nil, err
+	}
+
+	// A leader whose own participation was verified still resubmits for the rejected members
+	own := !achievement.IsTeam() || ref.Status == StatusRejected
+	if own {
+		if err := s.checkEvidence(ctx, ref); err != nil {
+			return nil, err
+		}
+		if err := s.transition(ref, StatusSubmitted, studentID, RoleStudent, nil, markSubmitted); err != nil {
+			return nil, err
+		}
 	}
 
-	return s.GetAchievementByID(ctx, refID)
+	// Members sent back to draft by a change request are resubmitted with the rejected ones
+	moved := 0
+	for _, from := range []string{StatusRejected, StatusDraft} {
+		n, err := s.moveTeam(ref, from, StatusSubmitted, studentID, func(member *model.AchievementReference) error {
+			return s.checkEvidence(ctx, member)
+		}, markSubmitted)
+		if err != nil {
+			return nil, err
+		}
+		moved += n
+	}
+	if !own && moved == 0 {
+		return nil, fmt.Errorf("%w: no team member's participation was rejected or sent back for changes", ErrInvalidTransition)
+	}
+
+	return s.GetAchievementByID(ctx, ref.ID)
+}
+
+// Withdraw a submitted achievement back to draft before it is reviewed (Student)
+func (s *AchievementService) WithdrawAchievement(ctx context.Context, refID, studentID string) (*model.AchievementResponse, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check ownership
+	if ref.StudentID != studentID {
+		return nil, fmt.Errorf("%w: you can only withdraw your own achievements", ErrUnauthorized)
+	}
+	if _, err := s.requireTeamLeader(ctx, ref, studentID, "withdraw"); err != nil {
+		return nil, err
+	}
+
+	if ref.VerifiedAt != nil || ref.VerifiedBy != nil {
+		return nil, fmt.Errorf("%w: achievement has already been reviewed", ErrInvalidTransition)
+	}
+
+	if err := s.transition(ref, StatusDraft, studentID, RoleStudent, nil, clearSubmitted); err != nil {
+		return nil, err
+	}
+
+	_, err = s.moveTeam(ref, StatusSubmitted, StatusDraft, studentID, nil, clearSubmitted)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetAchievementByID(ctx, ref.ID)
 }
 
 // FR-005: Delete Achievement (Student - only draft)
 func (s *AchievementService) DeleteAchievement(ctx context.Context, refID, studentID string) error {
-	ref, err := s.repo.GetAchievementReference(refID)
+	ref, err := s.getReference(refID)
 	if err != nil {
-		return fmt.Errorf("achievement not found: %w", err)
+		return err
 	}
 
 	// Check ownership
 	if ref.StudentID != studentID {
-		return errors.New("unauthorized: you can only delete your own achievements")
+		return fmt.Errorf("%w: you can only delete your own achievements", ErrUnauthorized)
+	}
+	achievement, err := s.requireTeamLeader(ctx, ref, studentID, "delete")
+	if err != nil {
+		return err
 	}
 
-	// Check status - only draft can be deleted
-	if ref.Status != "draft" {
-		return errors.New("can only delete achievements in draft status")
+	// Teammates' references would be left pointing at a deleted document
+	if achievement.IsTeam() {
+		deletable, err := s.teamDeletable(ref)
+		if err != nil {
+			return err
+		}
+		if !deletable {
+			return fmt.Errorf("%w: a team achievement can only be deleted while every member's participation is in draft", ErrInvalidTransition)
+		}
 	}
 
-	// Soft delete in MongoDB
-	err = s.repo.SoftDeleteAchievementMongo(ctx, ref.MongoAchievementID)
+	duplicates, err := s.duplicateIDs(ctx, ref.MongoAchievementID)
 	if err != nil {
-		return fmt.Errorf("failed to delete achievement: %w", err)
+		return err
+	}
+
+	// Update status in PostgreSQL first, so a concurrent submit cannot win over a hidden document
+	if err := s.transition(ref, StatusDeleted, studentID, RoleStudent, nil, clearSubmitted); err != nil {
+		return err
 	}
 
-	// Update status in PostgreSQL
-	err = s.repo.UpdateAchievementStatus(refID, "deleted", nil)
+	_, err = s.moveTeam(ref, StatusDraft, StatusDeleted, studentID, nil, clearSubmitted)
 	if err != nil {
-		return fmt.Errorf("failed to update reference status: %w", err)
+		return err
 	}
 
-	return nil
+	// Soft delete in MongoDB
+	if err := s.repo.SoftDeleteAchievementMongo(ctx, ref.MongoAchievementID); err != nil {
+		return fmt.Errorf("failed to delete achievement: %w", err)
+	}
+
+	// The remaining copies are no longer duplicates of the deleted one
+	return s.refreshDuplicates(ctx, duplicates)
 }
 
-// FR-007: Verify Achievement (Lecturer)
-func (s *AchievementService) VerifyAchievement(ctx context.Context, refID, lecturerID string, req model.VerifyAchievementRequest) (*model.AchievementResponse, error) {
-	ref, err := s.repo.GetAchievementReference(refID)
+// FR-007: Verify Achievement (Lecturer or Admin) - signs off the current approval stage
+func (s *AchievementService) VerifyAchievement(ctx context.Context, refID, verifierID, verifierRole string, req model.VerifyAchievementRequest) (*model.AchievementResponse, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
+	}
+
+	achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
 	if err != nil {
-		return nil, fmt.Errorf("achievement not found: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrAchievementNotFound, err)
 	}
 
-	// Check status - only submitted can be verified/rejected
-	if ref.Status != "rejected" && ref.Status != "submitted" {
-		return nil, errors.New("can only re-verify achievements that had been rejected or are currently submitted")
-	} 
+	// The document ID resolves to the leader; each member's advisor verifies that member's reference
+	if achievement.IsTeam() && ref.ID != refID {
+		return nil, &ValidationError{Fields: []model.FieldError{
+			{Field: "id", Message: "team achievements are verified per member; use the member's referenceId"},
+		}}
+	}
 
-	if req.Action == "verify" {
-		err = s.repo.VerifyAchievement(refID, lecturerID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to verify achievement: %w", err)
+	stage, err := s.currentStage(achievement, ref.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check advisor relationship / stage permission
+	if s.verifierAuth != nil {
+		if err := s.verifierAuth.AuthorizeStage(*stage, verifierID, ref.StudentID); err != nil {
+			return nil, err
 		}
-	} else if req.Action == "reject" {
+	}
+
+	switch req.Action {
+	case "verify":
+		var final bool
+		if final, err = s.isFinalStage(achievement, stage); err != nil {
+			return nil, err
+		}
+		if !final {
+			next := ApprovalStatus(stage.Name)
+			err = s.transition(ref, next, verifierID, verifierRole, req.Note, nil)
+			break
+		}
+		err = s.transition(ref, StatusVerified, verifierID, verifierRole, req.Note, func(change *model.AchievementTransition) {
+			change.VerifiedBy = &verifierID
+		})
+	case "reject":
 		if req.Note == nil || *req.Note == "" {
 			return nil, errors.New("rejection note is required")
 		}
-		err = s.repo.RejectAchievement(refID, *req.Note)
+		err = s.transition(ref, StatusRejected, verifierID, verifierRole, req.Note, func(change *model.AchievementTransition) {
+			change.RejectionNote = req.Note
+		})
+	default:
+		return nil, errors.New("invalid action: must be 'verify' or 'reject'")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetAchievementByID(ctx, ref.ID)
+}
+
+// Verify or reject several achievements; one failing item does not abort the rest
+func (s *AchievementService) VerifyAchievementsBatch(ctx context.Context, verifierID, verifierRole string, req model.BatchVerifyRequest) *model.BatchVerifyResponse {
+	response := &model.BatchVerifyResponse{
+		Results: make([]model.BatchVerifyResult, 0, len(req.Items)),
+	}
+
+	for _, item := range req.Items {
+		result := model.BatchVerifyResult{
+			AchievementID: item.AchievementID,
+			Action:        item.Action,
+		}
+
+		achievement, err := s.VerifyAchievement(ctx, item.AchievementID, verifierID, verifierRole, model.VerifyAchievementRequest{
+			Action: item.Action,
+			Note:   item.Note,
+		})
+		switch {
+		case err == nil:
+			result.Result = "succeeded"
+			result.Status = achievement.Status
+			response.Succeeded++
+		case errors.Is(err, ErrInvalidTransition):
+			result.Result = "skipped"
+			response.Skipped++
+		case errors.Is(err, ErrUnauthorized):
+			result.Result = "forbidden"
+			response.Forbidden++
+		case errors.Is(err, ErrAchievementNotFound):
+			result.Result = "not_found"
+			response.NotFound++
+		default:
+			result.Result = "failed"
+			response.Failed++
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to reject achievement: %w", err)
+			result.Error = err.Error()
 		}
-	} else {
-		return nil, errors.New("invalid action: must be 'verify' or 'reject'")
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response
+}
+
+// Get status transition history of an achievement
+func (s *AchievementService) GetStatusHistory(refID string) ([]model.AchievementStatusHistory, error) {
+	ref, err := s.getReference(refID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.GetAchievementByID(ctx, refID)
+	return s.repo.GetStatusHistory(ref.ID)
 }
 
 // Get Achievements by Student ID
@@ -234,40 +544,132 @@ func (s *AchievementService) GetAllAchievementsWithFilter(ctx context.Context, f
 	}
 
 	var results []model.AchievementResponse
+	var refIDs []string
 	for _, achievement := range achievements {
 		// Get reference from PostgreSQL
-		ref, err := s.repo.GetAchievementReferenceByMongoID(achievement.ID.Hex())
+		ref, err := s.filteredReference(&achievement, filter)
 		if err != nil {
 			continue
 		}
 
 		results = append(results, *s.combineAchievementResponse(&achievement, ref))
+		refIDs = append(refIDs, ref.ID)
 	}
+	s.withCommentCounts(results, refIDs)
 
 	return results, total, nil
 }
 
+// filteredReference picks the filtered student's reference to a team achievement, the creator's otherwise
+func (s *AchievementService) filteredReference(achievement *model.Achievement, filter model.AchievementFilter) (*model.AchievementReference, error) {
+	if achievement.IsTeam() && filter.StudentID != nil {
+		refs, err := s.repo.GetAchievementReferencesByMongoID(achievement.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		for i := range refs {
+			if refs[i].StudentID == *filter.StudentID {
+				return &refs[i], nil
+			}
+		}
+	}
+	return s.repo.GetAchievementReferenceByMongoID(achievement.ID.Hex())
+}
+
 func (s *AchievementService) GetStatistics(ctx context.Context, studentIDs []string) (*model.AchievementStatistics, error) {
-	stats, err := s.repo.GetAchievementStatistics(ctx, studentIDs)
+	return s.GetStatisticsForPeriod(ctx, studentIDs, nil)
+}
+
+// GetStatisticsForPeriod limits counts and points to achievements dated in the period (nil for all
+// time); verification turnaround and overdue submissions always cover the current workload
+func (s *AchievementService) GetStatisticsForPeriod(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) (*model.AchievementStatistics, error) {
+	// An empty, non-nil scope (a lecturer without advisees) has nothing to count
+	empty := studentIDs != nil && len(studentIDs) == 0
+
+	stats := &model.AchievementStatistics{
+		ByType:             make(map[string]int),
+		ByStatus:           make(map[string]int),
+		ByCompetitionLevel: make(map[string]int),
+		TopStudents:        []model.StudentAchievementCount{},
+	}
+	if !empty {
+		var err error
+		if stats, err = s.repo.GetAchievementStatistics(ctx, studentIDs, period); err != nil {
+			return nil, err
+		}
+	}
+	if period != nil {
+		stats.Period = period.Code
+	}
+
+	if err := s.fillTypeBuckets(stats.ByType); err != nil {
+		return nil, err
+	}
+
+	s.applyExpiryPolicy(&stats.PointsBreakdown)
+	stats.ExpiredWeight = s.expiredWeight
+
+	stats.VerificationSLADays = s.slaDays
+	stats.AvgTimeToVerify = []model.VerifierTurnaround{}
+	if empty {
+		return stats, nil
+	}
+
+	// Totals and the leaderboard go through the same expiry weighting and period caps as the
+	// student report, so a student's leaderboard total matches their own report
+	engine, periods, err := s.pointCaps()
 	if err != nil {
 		return nil, err
 	}
+	students, err := s.repo.GetStudentPoints(ctx, studentIDs, period)
+	if err != nil {
+		return nil, err
+	}
+	s.capStudentPoints(students, engine, periods)
+	stats.TotalPoints = 0
+	for _, student := range students {
+		stats.TotalPoints += student.TotalPoints
+	}
 
-	// Get top students
-	topStudents, err := s.repo.GetTopStudents(ctx, 10)
+	if studentIDs != nil {
+		if students, err = s.repo.GetStudentPoints(ctx, nil, period); err == nil {
+			s.capStudentPoints(students, engine, periods)
+		}
+	}
 	if err == nil {
-		stats.TopStudents = topStudents
+		stats.TopStudents = s.rankStudents(students, 10)
+	}
+
+	if turnaround, err := s.repo.GetVerifierTurnaround(studentIDs); err == nil {
+		stats.AvgTimeToVerify = turnaround
+	}
+	if overdue, err := s.repo.GetOverdueAchievementReferences(studentIDs, s.slaCutoff(time.Now())); err == nil {
+		stats.OverdueSubmissions = len(overdue)
 	}
 
 	return stats, nil
 }
 
 func (s *AchievementService) GetStudentReport(ctx context.Context, studentID string) (*model.StudentReportResponse, error) {
+	return s.GetStudentReportForPeriod(ctx, studentID, nil)
+}
+
+// GetStudentReportForPeriod reports only the achievements dated in the period (nil for all time)
+func (s *AchievementService) GetStudentReportForPeriod(ctx context.Context, studentID string, period *model.AcademicPeriod) (*model.StudentReportResponse, error) {
 	// Get achievements
 	achievements, err := s.GetAchievementsByStudentID(ctx, studentID)
 	if err != nil {
 		return nil, err
 	}
+	if period != nil {
+		inPeriod := []model.AchievementResponse{}
+		for _, achievement := range achievements {
+			if period.Contains(achievement.ReportDate()) {
+				inPeriod = append(inPeriod, achievement)
+			}
+		}
+		achievements = inPeriod
+	}
 
 	studentName, nim := s.repo.GetStudentInfo(studentID)
 
@@ -279,53 +681,123 @@ func (s *AchievementService) GetStudentReport(ctx context.Context, studentID str
 		TotalAchievements: len(achievements),
 		ByType:            make(map[string]int),
 		ByStatus:          make(map[string]int),
+		ExpiredWeight:     s.expiredWeight,
 		Achievements:      achievements,
 	}
+	if period != nil {
+		report.Period = period.Code
+	}
+
+	if err := s.fillTypeBuckets(report.ByType); err != nil {
+		return nil, err
+	}
 
+	var verified []model.Achievement
 	for _, achievement := range achievements {
 		report.ByType[achievement.AchievementType]++
 		report.ByStatus[achievement.Status]++
-		report.TotalPoints += achievement.Points
+		report.Add(achievement.Status, achievement.Points)
+		if achievement.ExpiredAt != nil {
+			report.AddExpired(achievement.Status, achievement.Points)
+		}
+		if achievement.Status == StatusVerified {
+			verified = append(verified, s.countedAchievement(achievement.Achievement))
+		}
+	}
+	s.applyExpiryPolicy(&report.PointsBreakdown)
+	report.RawTotalPoints = report.VerifiedPoints
+
+	if err := s.applyPointCaps(report, verified); err != nil {
+		return nil, err
 	}
 
 	return report, nil
 }
 
-// Upload Attachment
-func (s *AchievementService) UploadAttachment(ctx context.Context, refID, studentID string, req model.UploadAttachmentRequest) error {
-	ref, err := s.repo.GetAchievementReference(refID)
-	if err != nil {
-		return fmt.Errorf("achievement not found: %w", err)
+// Helper functions
+
+// getReference accepts either the PostgreSQL reference ID or the MongoDB achievement ID used in URLs
+func (s *AchievementService) getReference(id string) (*model.AchievementReference, error) {
+	ref, err := s.repo.GetAchievementReference(id)
+	if err == nil {
+		return ref, nil
 	}
 
-	// Check ownership
-	if ref.StudentID != studentID {
-		return errors.New("unauthorized: you can only upload attachments to your own achievements")
+	ref, mongoErr := s.repo.GetAchievementReferenceByMongoID(id)
+	if mongoErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAchievementNotFound, err)
 	}
+	return ref, nil
+}
 
-	attachment := model.Attachment{
-		FileName: req.FileName,
-		FileURL:  req.FileURL,
-		FileType: req.FileType,
+// transition validates a status change against the state machine, then applies it together with
+// its history row; it fails with ErrInvalidTransition if the reference moved on since it was read
+func (s *AchievementService) transition(ref *model.AchievementReference, to, actorID, actorRole string, note *string, update func(change *model.AchievementTransition)) error {
+	if err := s.stateMachine.CanTransition(ref.Status, to, actorRole); err != nil {
+		return err
+	}
+
+	// Columns the transition does not set keep their current values
+	change := model.AchievementTransition{
+		History: model.AchievementStatusHistory{
+			AchievementReferenceID: ref.ID,
+			FromStatus:             ref.Status,
+			ToStatus:               to,
+			ActorID:                actorID,
+			ActorRole:              actorRole,
+			Note:                   note,
+		},
+		SubmittedAt:   ref.SubmittedAt,
+		RejectionNote: ref.RejectionNote,
+	}
+	if update != nil {
+		update(&change)
+	}
+
+	applied, err := s.repo.TransitionAchievement(&change)
+	if err != nil {
+		return fmt.Errorf("failed to update achievement status: %w", err)
+	}
+	if !applied {
+		return fmt.Errorf("%w: achievement is no longer %s", ErrInvalidTransition, ref.Status)
 	}
 
-	return s.repo.AddAttachmentMongo(ctx, ref.MongoAchievementID, attachment)
+	ref.Status = to
+	ref.SubmittedAt = change.SubmittedAt
+	ref.RejectionNote = change.RejectionNote
+	return nil
+}
+
+// markSubmitted stamps the submission time and clears a previous rejection note
+func markSubmitted(change *model.AchievementTransition) {
+	now := time.Now()
+	change.SubmittedAt = &now
+	change.RejectionNote = nil
+}
+
+// clearSubmitted takes an achievement out of the review queue
+func clearSubmitted(change *model.AchievementTransition) {
+	change.SubmittedAt = nil
 }
 
-// Helper functions
 func (s *AchievementService) combineAchievementResponse(achievement *model.Achievement, ref *model.AchievementReference) *model.AchievementResponse {
-	return &model.AchievementResponse{
+	response := &model.AchievementResponse{
 		Achievement:   *achievement,
+		ReferenceID:   ref.ID,
 		Status:        ref.Status,
 		SubmittedAt:   ref.SubmittedAt,
 		VerifiedAt:    ref.VerifiedAt,
 		VerifiedBy:    ref.VerifiedBy,
 		RejectionNote: ref.RejectionNote,
 	}
+	// A team member's view shows their own share of the points
+	response.Points = achievement.PointsFor(ref.StudentID)
+	return response
 }
 
 func (s *AchievementService) combineMultipleAchievements(ctx context.Context, refs []model.AchievementReference) ([]model.AchievementResponse, error) {
 	var results []model.AchievementResponse
+	var refIDs []string
 
 	for _, ref := range refs {
 		achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
@@ -334,7 +806,9 @@ func (s *AchievementService) combineMultipleAchievements(ctx context.Context, re
 			continue
 		}
 		results = append(results, *s.combineAchievementResponse(achievement, &ref))
+		refIDs = append(refIDs, ref.ID)
 	}
+	s.withCommentCounts(results, refIDs)
 
 	return results, nil
 }
@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"student-report/app/model"
 	"student-report/app/repository"
@@ -11,6 +15,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+const maxBatchVerifyItems = 100
+
 // Create Achievement (FR-003) - Mahasiswa
 func CreateAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	// Get student_id from context (set by auth middleware)
@@ -41,26 +47,20 @@ func CreateAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 		})
 	}
 
-	if req.AchievementType == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Achievement type wajib diisi",
-			"success": false,
-		})
-	}
-	
-	if req.Title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Title wajib diisi",
-			"success": false,
-		})
-	}
-
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 
 	ctx := context.Background()
 	achievement, err := achievementService.CreateAchievement(ctx, student.ID, req)
 	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Data achievement tidak valid",
+				"errors":  validationErr.Fields,
+				"success": false,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal membuat achievement",
 			"error":   err.Error(),
@@ -104,7 +104,7 @@ func GetAchievementByIDService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database
 	})
 }
 
-// Update Achievement - Mahasiswa (draft only)
+// Update Achievement - Mahasiswa (draft or rejected)
 func UpdateAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	achievementID := c.Params("id")
 	userID := c.Locals("user_id").(string)
@@ -133,7 +133,15 @@ func UpdateAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	ctx := context.Background()
 	achievement, err := achievementService.UpdateAchievement(ctx, achievementID, student.ID, req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Data achievement tidak valid",
+				"errors":  validationErr.Fields,
+				"success": false,
+			})
+		}
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
 			"message": "Gagal update achievement",
 			"error":   err.Error(),
 			"success": false,
@@ -163,53 +171,54 @@ func SubmitAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	}
 
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+	ctx := context.Background()
 	
-	ref, err := achievementRepo.GetAchievementReferenceByMongoID(achievementID)
+	achievement, err := achievementService.SubmitForVerification(ctx, achievementID, student.ID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Achievement tidak ditemukan",
-			"error":   err.Error(),
-			"success": false,
-		})
-	}
-	
-	// Check ownership
-	if ref.StudentID != student.ID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Unauthorized: you can only submit your own achievements",
-			"success": false,
-		})
+		return submitErrorResponse(c, err, "Gagal submit achievement")
 	}
 
-	// Check status - only draft can be submitted
-	if ref.Status != "draft" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Can only submit achievements in draft status",
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    achievement,
+		"message": "Achievement berhasil disubmit untuk verifikasi",
+		"success": true,
+	})
+}
+
+// Resubmit Rejected Achievement - Mahasiswa
+func ResubmitAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	// Get student record
+	studentRepo := repository.NewStudentRepository(db)
+	student, err := studentRepo.GetStudentByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
 			"success": false,
 		})
 	}
 
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 	ctx := context.Background()
-	
-	achievement, err := achievementService.SubmitForVerification(ctx, ref.ID, student.ID)
+
+	achievement, err := achievementService.ResubmitAchievement(ctx, achievementID, student.ID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Gagal submit achievement",
-			"error":   err.Error(),
-			"success": false,
-		})
+		return submitErrorResponse(c, err, "Gagal resubmit achievement")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data":    achievement,
-		"message": "Achievement berhasil disubmit untuk verifikasi",
+		"message": "Achievement berhasil disubmit ulang untuk verifikasi",
 		"success": true,
 	})
 }
 
-// FR-005: Delete Achievement (draft only)
-func DeleteAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+// Withdraw Submitted Achievement - Mahasiswa
+func WithdrawAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	achievementID := c.Params("id")
 	userID := c.Locals("user_id").(string)
 
@@ -224,30 +233,47 @@ func DeleteAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	}
 
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
-	
-	ref, err := achievementRepo.GetAchievementReferenceByMongoID(achievementID)
+	achievementService := NewAchievementService(achievementRepo)
+	ctx := context.Background()
+
+	achievement, err := achievementService.WithdrawAchievement(ctx, achievementID, student.ID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Achievement tidak ditemukan",
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal menarik kembali achievement",
 			"error":   err.Error(),
 			"success": false,
 		})
 	}
-	
-	// Check ownership
-	if ref.StudentID != student.ID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Unauthorized: you can only delete your own achievements",
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    achievement,
+		"message": "Achievement berhasil ditarik kembali ke draft",
+		"success": true,
+	})
+}
+
+// FR-005: Delete Achievement (draft only)
+func DeleteAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	// Get student record
+	studentRepo := repository.NewStudentRepository(db)
+	student, err := studentRepo.GetStudentByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "Student profile tidak ditemukan",
 			"success": false,
 		})
 	}
 
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 	ctx := context.Background()
 	
-	err = achievementService.DeleteAchievement(ctx, ref.ID, student.ID)
+	err = achievementService.DeleteAchievement(ctx, achievementID, student.ID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
 			"message": "Gagal hapus achievement",
 			"error":   err.Error(),
 			"success": false,
@@ -281,22 +307,13 @@ func VerifyAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	}
 
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
-	
-	ref, err := achievementRepo.GetAchievementReferenceByMongoID(achievementID)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Achievement tidak ditemukan",
-			"error":   err.Error(),
-			"success": false,
-		})
-	}
-
-	achievementService := NewAchievementService(achievementRepo)
+	achievementService := newVerifierAchievementService(c, db, achievementRepo)
 	ctx := context.Background()
 	
-	achievement, err := achievementService.VerifyAchievement(ctx, ref.ID, userID, req)
+	role, _ := c.Locals("role").(string)
+	achievement, err := achievementService.VerifyAchievement(ctx, achievementID, userID, role, req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
 			"message": "Gagal memproses verifikasi",
 			"error":   err.Error(),
 			"success": false,
@@ -315,6 +332,46 @@ func VerifyAchievementService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	})
 }
 
+// Batch Verify/Reject Achievements (Dosen Wali)
+func VerifyAchievementsBatchService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	userID := c.Locals("user_id").(string)
+
+	var req model.BatchVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	if len(req.Items) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Items wajib diisi",
+			"success": false,
+		})
+	}
+
+	if len(req.Items) > maxBatchVerifyItems {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": fmt.Sprintf("Maksimal %d achievement per batch", maxBatchVerifyItems),
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := newVerifierAchievementService(c, db, achievementRepo)
+	ctx := context.Background()
+
+	role, _ := c.Locals("role").(string)
+	report := achievementService.VerifyAchievementsBatch(ctx, userID, role, req)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    report,
+		"message": fmt.Sprintf("%d dari %d achievement berhasil diproses", report.Succeeded, len(req.Items)),
+		"success": true,
+	})
+}
+
 // Get My Achievements (Mahasiswa)
 func GetMyAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	userID := c.Locals("user_id").(string)
@@ -363,9 +420,8 @@ func GetAdviseesAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Dat
 		})
 	}
 
-	// Get advisees (students under this lecturer)
-	studentRepo := repository.NewStudentRepository(db)
-	advisees, err := studentRepo.GetStudentByAdvisorID(lecturerID)
+	// Get advisees (students under this lecturer and advisors delegating to them)
+	studentIDs, err := adviseeIDsWithDelegations(db, lecturerRepo, lecturerID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal mengambil data mahasiswa bimbingan",
@@ -374,7 +430,7 @@ func GetAdviseesAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Dat
 		})
 	}
 
-	if len(advisees) == 0 {
+	if len(studentIDs) == 0 {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"data":    []interface{}{},
 			"total":   0,
@@ -383,20 +439,76 @@ func GetAdviseesAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Dat
 		})
 	}
 
-	// Get student IDs
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ctx := context.Background()
+	achievements, err := achievementService.GetAchievementsForAdvisees(ctx, studentIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil data achievements",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	// Likely duplicates are flagged per achievement in possibleDuplicates
+	flagged := 0
+	for _, achievement := range achievements {
+		if len(achievement.Duplicates) > 0 {
+			flagged++
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":              achievements,
+		"total":             len(achievements),
+		"flaggedDuplicates": flagged,
+		"success":           true,
+	})
+}
+
+// Get Submissions Past the Verification SLA (Dosen Wali: advisees, Admin: all)
+func GetOverdueAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	userID := c.Locals("user_id").(string)
+	role, _ := c.Locals("role").(string)
+
 	var studentIDs []string
-	for _, advisee := range advisees {
-		studentIDs = append(studentIDs, advisee.ID)
+	if role != RoleAdmin {
+		lecturerRepo := repository.NewLecturerRepository(db)
+		lecturerID, err := lecturerRepo.GetLecturerIDByUserID(userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Lecturer profile tidak ditemukan",
+				"success": false,
+			})
+		}
+
+		studentIDs, err = adviseeIDsWithDelegations(db, lecturerRepo, lecturerID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Gagal mengambil data mahasiswa bimbingan",
+				"error":   err.Error(),
+				"success": false,
+			})
+		}
+		if len(studentIDs) == 0 {
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"data":    []interface{}{},
+				"total":   0,
+				"success": true,
+			})
+		}
 	}
 
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 
 	ctx := context.Background()
-	achievements, err := achievementService.GetAchievementsForAdvisees(ctx, studentIDs)
+	achievements, err := achievementService.GetOverdueAchievements(ctx, studentIDs)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"message": "Gagal mengambil data achievements",
+			"message": "Gagal mengambil achievement yang melewati SLA",
 			"error":   err.Error(),
 			"success": false,
 		})
@@ -405,10 +517,59 @@ func GetAdviseesAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Dat
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data":    achievements,
 		"total":   len(achievements),
+		"slaDays": verificationSLADays(),
 		"success": true,
 	})
 }
 
+// Get Achievements Expiring Soon (?within=30d; Mahasiswa: own, Dosen Wali: advisees, Admin: all)
+func GetExpiringAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	within, err := ParseExpiringWithin(c.Query("within"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Parameter within tidak valid",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	studentIDs, err := achievementReaderScope(c, db)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Tidak dapat menentukan akses achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	if studentIDs != nil && len(studentIDs) == 0 {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"data":    []interface{}{},
+			"total":   0,
+			"success": true,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ctx := context.Background()
+	achievements, err := achievementService.GetExpiringAchievements(ctx, studentIDs, within)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil achievement yang akan kedaluwarsa",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":       achievements,
+		"total":      len(achievements),
+		"withinDays": int(within.Hours() / 24),
+		"success":    true,
+	})
+}
+
 // FR-010: Get All Achievements (Admin)
 func GetAllAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
@@ -431,69 +592,157 @@ func GetAllAchievementsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database
 	})
 }
 
-// Upload Attachment
-func UploadAttachmentService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+// Get Achievement Status History
+func GetAchievementHistoryService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	achievementID := c.Params("id")
-	userID := c.Locals("user_id").(string)
 
-	// Get student record
-	studentRepo := repository.NewStudentRepository(db)
-	student, err := studentRepo.GetStudentByUserID(userID)
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	history, err := achievementService.GetStatusHistory(achievementID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Student profile tidak ditemukan",
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal mengambil riwayat status achievement",
+			"error":   err.Error(),
 			"success": false,
 		})
 	}
 
-	var req model.UploadAttachmentRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
-			"success": false,
-		})
-	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    history,
+		"total":   len(history),
+		"success": true,
+	})
+}
 
-	if req.FileName == "" || req.FileURL == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "File name dan URL wajib diisi",
-			"success": false,
-		})
-	}
+// Get Achievement Revisions
+func GetAchievementRevisionsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
 
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
-	
-	ref, err := achievementRepo.GetAchievementReferenceByMongoID(achievementID)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ctx := context.Background()
+	revisions, err := achievementService.GetRevisions(ctx, achievementID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Achievement tidak ditemukan",
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal mengambil revisi achievement",
 			"error":   err.Error(),
 			"success": false,
 		})
 	}
-	
-	// Check ownership
-	if ref.StudentID != student.ID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"message": "Unauthorized: you can only upload attachments to your own achievements",
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    revisions,
+		"total":   len(revisions),
+		"success": true,
+	})
+}
+
+// Diff Between Two Achievement Revisions
+func DiffAchievementRevisionsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementID := c.Params("id")
+
+	fromRevision, errA := strconv.Atoi(c.Params("a"))
+	toRevision, errB := strconv.Atoi(c.Params("b"))
+	if errA != nil || errB != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Nomor revisi harus berupa angka",
 			"success": false,
 		})
 	}
 
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
+
 	ctx := context.Background()
-	
-	err = achievementService.UploadAttachment(ctx, ref.ID, student.ID, req)
+	diff, err := achievementService.DiffRevisions(ctx, achievementID, fromRevision, toRevision)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Gagal upload attachment",
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal membandingkan revisi achievement",
 			"error":   err.Error(),
 			"success": false,
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Attachment berhasil diupload",
+		"data":    diff,
 		"success": true,
 	})
-}
\ No newline at end of file
+}
+
+// adviseeIDsWithDelegations returns the lecturer's own advisees plus those of advisors currently delegating to them
+func adviseeIDsWithDelegations(db *sql.DB, lecturerRepo *repository.LecturerRepository, lecturerID string) ([]string, error) {
+	delegatorIDs, err := lecturerRepo.GetActiveDelegatorIDs(lecturerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	studentRepo := repository.NewStudentRepository(db)
+	studentIDs := []string{}
+	for _, advisorID := range append([]string{lecturerID}, delegatorIDs...) {
+		advisees, err := studentRepo.GetStudentByAdvisorID(advisorID)
+		if err != nil {
+			return nil, err
+		}
+		for _, advisee := range advisees {
+			studentIDs = append(studentIDs, advisee.ID)
+		}
+	}
+	return studentIDs, nil
+}
+
+// newVerifierAchievementService scopes verification to the caller's advisees and approval permissions; admins are not restricted
+func newVerifierAchievementService(c *fiber.Ctx, db *sql.DB, achievementRepo *repository.AchievementRepository) *AchievementService {
+	achievementService := NewAchievementService(achievementRepo)
+	if role, _ := c.Locals("role").(string); role != RoleAdmin {
+		permissions, _ := c.Locals("permissions").([]string)
+		achievementService.SetVerificationAuthorizer(NewAdvisorAuthorizer(
+			repository.NewStudentRepository(db),
+			repository.NewLecturerRepository(db),
+			permissions,
+		))
+	}
+	return achievementService
+}
+
+// submitErrorResponse lists missing evidence so the student knows what to attach before submitting again
+func submitErrorResponse(c *fiber.Ctx, err error, message string) error {
+	var evidenceErr *MissingEvidenceError
+	if errors.As(err, &evidenceErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message":         "Bukti pendukung belum lengkap",
+			"missingEvidence": evidenceErr.Missing,
+			"success":         false,
+		})
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Data achievement tidak valid",
+			"errors":  validationErr.Fields,
+			"success": false,
+		})
+	}
+	return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+		"message": message,
+		"error":   err.Error(),
+		"success": false,
+	})
+}
+
+// achievementErrorStatus maps service errors to HTTP status codes
+func achievementErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrAchievementNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrUnauthorized):
+		return fiber.StatusForbidden
+	case errors.Is(err, ErrInvalidTransition):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrMissingEvidence):
+		return fiber.StatusUnprocessableEntity
+	default:
+		return fiber.StatusBadRequest
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"student-report/app/model"
+)
+
+// Achievement lifecycle statuses stored in achievement_references.status
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusVerified  = "verified"
+	StatusRejected  = "rejected"
+	StatusDeleted   = "deleted"
+)
+
+// Roles allowed to trigger a transition (matches the role claim in the JWT)
+const (
+	RoleStudent  = "student"
+	RoleLecturer = "lecturer"
+	RoleAdmin    = "admin"
+)
+
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// StatusTransition declares a single allowed move between two statuses
+type StatusTransition struct {
+	From  string
+	To    string
+	Roles []string
+}
+
+// AchievementStateMachine is the single place where the achievement lifecycle is defined
+type AchievementStateMachine struct {
+	transitions []StatusTransition
+	editable    map[string]bool
+}
+
+func NewAchievementStateMachine() *AchievementStateMachine {
+	return &AchievementStateMachine{
+		transitions: []StatusTransition{
+			{From: StatusDraft, To: StatusSubmitted, Roles: []string{RoleStudent}},
+			{From: StatusDraft, To: StatusDeleted, Roles: []string{RoleStudent}},
+			// Withdrawn by the student, or sent back by a reviewer's change request
+			{From: StatusSubmitted, To: StatusDraft, Roles: []string{RoleStudent, RoleLecturer, RoleAdmin}},
+			{From: StatusSubmitted, To: StatusVerified, Roles: []string{RoleLecturer, RoleAdmin}},
+			{From: StatusSubmitted, To: StatusRejected, Roles: []string{RoleLecturer, RoleAdmin}},
+			{From: StatusRejected, To: StatusSubmitted, Roles: []string{RoleStudent}},
+		},
+		editable: map[string]bool{
+			StatusDraft:    true,
+			StatusRejected: true,
+		},
+	}
+}
+
+// ApprovalStatus is the intermediate status reached once an approval stage signs off
+func ApprovalStatus(stageName string) string {
+	return model.ApprovalStatus(stageName)
+}
+
+// IsApprovalStatus reports whether status is an intermediate approval-chain status such as advisor_approved
+func IsApprovalStatus(status string) bool {
+	return model.IsApprovalStatus(status)
+}
+
+// IsPendingReview reports whether an achievement is waiting on some approver
+func IsPendingReview(status string) bool {
+	return model.IsPendingReview(status)
+}
+
+// CanTransition returns nil if role may move an achievement from one status to another
+func (m *AchievementStateMachine) CanTransition(from, to, role string) error {
+	// Intermediate approval statuses follow the same rules as the submitted -> verified step,
+	// except that they have already been reviewed: the student can no longer withdraw them,
+	// only a reviewer's change request sends them back to draft
+	lookupFrom, lookupTo := from, to
+	if IsApprovalStatus(from) {
+		if to == StatusDraft && role == RoleStudent {
+			return fmt.Errorf("%w: cannot move achievement from %s to %s", ErrInvalidTransition, from, to)
+		}
+		lookupFrom = StatusSubmitted
+	}
+	if IsApprovalStatus(to) {
+		lookupTo = StatusVerified
+	}
+
+	for _, t := range m.transitions {
+		if t.From != lookupFrom || t.To != lookupTo {
+			continue
+		}
+		for _, r := range t.Roles {
+			if r == role {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: role %s cannot move achievement from %s to %s", ErrInvalidTransition, role, from, to)
+	}
+	return fmt.Errorf("%w: cannot move achievement from %s to %s", ErrInvalidTransition, from, to)
+}
+
+// IsEditable reports whether achievement content may still be changed in this status
+func (m *AchievementStateMachine) IsEditable(status string) bool {
+	return m.editable[status]
+}
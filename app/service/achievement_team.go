@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"student-report/app/model"
+)
+
+// Team member roles; the creator leads the team and drives its edits and submissions
+const (
+	TeamRoleLeader = "leader"
+	TeamRoleMember = "member"
+)
+
+// normalizeTeam validates the requested team members and puts the creator first as leader.
+// A team of only the creator is a solo achievement (nil).
+func (s *AchievementService) normalizeTeam(creatorID string, members []model.TeamMember) ([]model.TeamMember, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	leader := model.TeamMember{StudentID: creatorID, Role: TeamRoleLeader}
+	team := []model.TeamMember{leader}
+	seen := map[string]bool{creatorID: true}
+
+	var fields []model.FieldError
+	for i, member := range members {
+		field := fmt.Sprintf("teamMembers[%d].studentId", i)
+		switch {
+		case member.StudentID == creatorID:
+			if member.Role != "" {
+				team[0].Role = member.Role
+			}
+			continue
+		case member.StudentID == "":
+			fields = append(fields, model.FieldError{Field: field, Message: "is required"})
+			continue
+		case seen[member.StudentID]:
+			fields = append(fields, model.FieldError{Field: field, Message: "is listed more than once"})
+			continue
+		}
+		seen[member.StudentID] = true
+
+		exists, err := s.repo.StudentExists(member.StudentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up team member %s: %w", member.StudentID, err)
+		}
+		if !exists {
+			fields = append(fields, model.FieldError{Field: field, Message: "student does not exist"})
+			continue
+		}
+		if member.Role == "" {
+			member.Role = TeamRoleMember
+		}
+		member.Points = 0
+		team = append(team, member)
+	}
+
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+	if len(team) == 1 {
+		return nil, nil
+	}
+	return team, nil
+}
+
+// requireTeamLeader loads the achievement and, for a team achievement, checks that the student leads it
+func (s *AchievementService) requireTeamLeader(ctx context.Context, ref *model.AchievementReference, studentID, action string) (*model.Achievement, error) {
+	achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+	if err != nil {
+		return nil, fmt.Errorf("achievement not found in MongoDB: %w", err)
+	}
+	if achievement.IsTeam() && achievement.StudentID != studentID {
+		return nil, fmt.Errorf("%w: only the team leader can %s a team achievement", ErrUnauthorized, action)
+	}
+	return achievement, nil
+}
+
+// teamEditable reports whether a team's shared document may change: no member's participation is
+// under review or already verified, and at least one member still has to revise it
+func (s *AchievementService) teamEditable(ref *model.AchievementReference) (bool, error) {
+	refs, err := s.repo.GetAchievementReferencesByMongoID(ref.MongoAchievementID)
+	if err != nil {
+		return false, err
+	}
+
+	editable := false
+	for _, member := range refs {
+		if IsPendingReview(member.Status) || member.Status == StatusVerified {
+			return false, nil
+		}
+		if s.stateMachine.IsEditable(member.Status) {
+			editable = true
+		}
+	}
+	return editable, nil
+}
+
+// teamDeletable reports whether a team's shared document may be deleted: every member's
+// participation is still a draft, so no verified, reviewed or rejected reference is left behind
+func (s *AchievementService) teamDeletable(ref *model.AchievementReference) (bool, error) {
+	refs, err := s.repo.GetAchievementReferencesByMongoID(ref.MongoAchievementID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range refs {
+		if member.Status != StatusDraft && member.Status != StatusDeleted {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// moveTeam applies a status change to the references of the other team members that are in the
+// from status, after check (if any) passes for each; it returns how many moved
+func (s *AchievementService) moveTeam(ref *model.AchievementReference, from, to, actorID string, check func(member *model.AchievementReference) error, update func(change *model.AchievementTransition)) (int, error) {
+	refs, err := s.repo.GetAchievementReferencesByMongoID(ref.MongoAchievementID)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for i := range refs {
+		member := &refs[i]
+		if member.ID == ref.ID || member.Status != from {
+			continue
+		}
+		if check != nil {
+			if err := check(member); err != nil {
+				return moved, fmt.Errorf("failed to update team member %s: %w", member.StudentID, err)
+			}
+		}
+		if err := s.transition(member, to, actorID, RoleStudent, nil, update); err != nil {
+			return moved, fmt.Errorf("failed to update team member %s: %w", member.StudentID, err)
+		}
+		moved++
+	}
+	return moved, nil
+}
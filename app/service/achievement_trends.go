@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"student-report/app/model"
+	"student-report/app/points"
+)
+
+// Trend granularities and groupings accepted by GetTrends
+const (
+	TrendGranularityMonth    = "month"
+	TrendGranularitySemester = "semester"
+	TrendGranularityYear     = "year"
+
+	TrendGroupByType   = "type"
+	TrendGroupByStatus = "status"
+	TrendGroupByLevel  = "level"
+)
+
+// GetTrends counts achievements over time for the dashboard charts; nil studentIDs covers everyone.
+// Every grouping counts references, so a team achievement counts once per member in scope, and
+// never deleted ones. Grouped by status, drafts and rejections show up as their own series; grouped
+// by type or level, only achievements submitted, under review or verified are counted.
+// Achievements are dated by their ReportDate. Semesters and years are the configured academic periods
+// (their code, or academic year), falling back to the default calendar (2025-GANJIL, 2025/2026) for
+// dates no period covers; buckets without achievements are filled in so the axis is continuous.
+func (s *AchievementService) GetTrends(ctx context.Context, studentIDs []string, granularity, groupBy string) (*model.AchievementTrends, error) {
+	if granularity == "" {
+		granularity = TrendGranularityMonth
+	}
+	if groupBy == "" {
+		groupBy = TrendGroupByType
+	}
+
+	var fields []model.FieldError
+	if granularity != TrendGranularityMonth && granularity != TrendGranularitySemester && granularity != TrendGranularityYear {
+		fields = append(fields, model.FieldError{Field: "granularity", Message: "must be month, semester or year"})
+	}
+	if groupBy != TrendGroupByType && groupBy != TrendGroupByStatus && groupBy != TrendGroupByLevel {
+		fields = append(fields, model.FieldError{Field: "groupBy", Message: "must be type, status or level"})
+	}
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+
+	trends := &model.AchievementTrends{
+		Granularity: granularity,
+		GroupBy:     groupBy,
+		Keys:        []string{},
+		Points:      []model.TrendPoint{},
+	}
+	// An empty, non-nil scope (a lecturer without advisees) has nothing to chart
+	if studentIDs != nil && len(studentIDs) == 0 {
+		return trends, nil
+	}
+
+	daily, err := s.repo.GetDailyStatistics(ctx, studentIDs, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.repo.GetAcademicPeriods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load academic periods: %w", err)
+	}
+
+	var first, last time.Time
+	buckets := newTrendBuckets()
+	keys := make(map[string]bool)
+	for _, row := range daily {
+		if groupBy != TrendGroupByStatus && !countsTowardTrend(row.Status) {
+			continue
+		}
+		day, err := time.Parse("2006-01-02", row.Day)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+
+		point := buckets.at(day, trendPeriod(day, granularity, periods))
+		point.Counts[row.Key] += row.Count
+		point.Total += row.Count
+		keys[row.Key] = true
+	}
+	if first.IsZero() {
+		return trends, nil
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		buckets.at(day, trendPeriod(day, granularity, periods))
+	}
+	trends.Points = buckets.chronological()
+
+	for key := range keys {
+		trends.Keys = append(trends.Keys, key)
+	}
+	sort.Strings(trends.Keys)
+
+	return trends, nil
+}
+
+// countsTowardTrend reports whether a reference in this status is a real achievement on the type and level charts
+func countsTowardTrend(status string) bool {
+	return IsPendingReview(status) || status == StatusVerified
+}
+
+// trendPeriod labels the bucket the day falls in
+func trendPeriod(day time.Time, granularity string, periods []model.AcademicPeriod) string {
+	switch granularity {
+	case TrendGranularitySemester:
+		return points.ResolvePeriodKey(day, points.CapPeriodSemester, periods)
+	case TrendGranularityYear:
+		return points.ResolvePeriodKey(day, points.CapPeriodAcademicYear, periods)
+	}
+	return day.Format("2006-01")
+}
+
+// trendBuckets holds the trend points by label along with the earliest day each one covers
+type trendBuckets struct {
+	points map[string]*model.TrendPoint
+	starts map[string]time.Time
+}
+
+func newTrendBuckets() *trendBuckets {
+	return &trendBuckets{
+		points: make(map[string]*model.TrendPoint),
+		starts: make(map[string]time.Time),
+	}
+}
+
+// at returns the bucket for the period the day falls in, creating it empty
+func (b *trendBuckets) at(day time.Time, period string) *model.TrendPoint {
+	point, exists := b.points[period]
+	if !exists {
+		point = &model.TrendPoint{Period: period, Counts: make(map[string]int)}
+		b.points[period] = point
+	}
+	if start, seen := b.starts[period]; !seen || day.Before(start) {
+		b.starts[period] = day
+	}
+	return point
+}
+
+// chronological lists the buckets by the first day they cover, since configured period
+// codes such as 2025-PENDEK do not sort by name
+func (b *trendBuckets) chronological() []model.TrendPoint {
+	result := make([]model.TrendPoint, 0, len(b.points))
+	for _, point := range b.points {
+		result = append(result, *point)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return b.starts[result[i].Period].Before(b.starts[result[j].Period])
+	})
+	return result
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"student-report/app/model"
+	"student-report/utils"
+)
+
+var achievementTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// builtinAchievementTypes are used until an admin stores type definitions; they carry no
+// evidence requirements, which only apply once configured (the migration seeds them)
+func builtinAchievementTypes() []model.AchievementTypeDefinition {
+	defaultPoints := map[string]int{
+		"academic": 30, "competition": 5, "organization": 20,
+		"publication": 20, "certification": 25, "other": 10,
+	}
+
+	definitions := make([]model.AchievementTypeDefinition, 0, len(achievementTypes))
+	for _, name := range achievementTypes {
+		required := append([]string{}, detailSections[name].required...)
+		definitions = append(definitions, model.AchievementTypeDefinition{
+			Name:                 name,
+			RequiredFields:       required,
+			DefaultPoints:        defaultPoints[name],
+			EvidenceRequirements: []model.EvidenceRequirement{},
+			IsActive:             true,
+		})
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions
+}
+
+// GetAchievementTypes returns the managed type list, falling back to the built-in types
+func (s *AchievementService) GetAchievementTypes() ([]model.AchievementTypeDefinition, error) {
+	definitions, err := s.repo.GetAchievementTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load achievement types: %w", err)
+	}
+	if len(definitions) == 0 {
+		return builtinAchievementTypes(), nil
+	}
+	return definitions, nil
+}
+
+// SaveAchievementType creates or replaces a type definition after checking its fields and schema
+func (s *AchievementService) SaveAchievementType(definition model.AchievementTypeDefinition) (*model.AchievementTypeDefinition, error) {
+	var fields []model.FieldError
+	add := func(field, message string) {
+		fields = append(fields, model.FieldError{Field: field, Message: message})
+	}
+
+	if !achievementTypeNamePattern.MatchString(definition.Name) {
+		add("name", "must be 2-50 lowercase letters, digits, '-' or '_' starting with a letter")
+	}
+	if definition.DefaultPoints < 0 {
+		add("defaultPoints", "cannot be negative")
+	}
+
+	known := presentDetailFields(model.AchievementDetails{})
+	for _, field := range definition.RequiredFields {
+		if _, ok := known[field]; !ok {
+			add("requiredFields", "unknown detail field "+field)
+		}
+	}
+	fields = append(fields, validateEvidenceRequirements(definition.EvidenceRequirements)...)
+	if definition.CustomFieldsSchema != nil {
+		if err := utils.CheckJSONSchema(definition.CustomFieldsSchema); err != nil {
+			add("customFieldsSchema", err.Error())
+		}
+	}
+
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+
+	if definition.RequiredFields == nil {
+		definition.RequiredFields = []string{}
+	}
+	if definition.EvidenceRequirements == nil {
+		definition.EvidenceRequirements = []model.EvidenceRequirement{}
+	}
+
+	// The first save replaces the built-in fallback, so store the built-ins alongside it
+	stored, err := s.repo.GetAchievementTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load achievement types: %w", err)
+	}
+	if len(stored) == 0 {
+		for _, builtin := range builtinAchievementTypes() {
+			if builtin.Name == definition.Name {
+				continue
+			}
+			if err := s.repo.SaveAchievementType(&builtin); err != nil {
+				return nil, fmt.Errorf("failed to save achievement type: %w", err)
+			}
+		}
+	}
+
+	if err := s.repo.SaveAchievementType(&definition); err != nil {
+		return nil, fmt.Errorf("failed to save achievement type: %w", err)
+	}
+	return &definition, nil
+}
+
+// UpdateAchievementType merges the provided fields into an existing type definition; omitted
+// fields, including isActive, keep their current values, and a null customFieldsSchema removes it
+func (s *AchievementService) UpdateAchievementType(name string, req model.UpdateAchievementTypeRequest) (*model.AchievementTypeDefinition, error) {
+	definition, err := s.findAchievementType(name)
+	if err != nil {
+		return nil, err
+	}
+	if definition == nil {
+		return nil, fmt.Errorf("%w: achievement type %s does not exist", ErrAchievementNotFound, name)
+	}
+
+	if req.Description != nil {
+		definition.Description = *req.Description
+	}
+	if req.RequiredFields != nil {
+		definition.RequiredFields = *req.RequiredFields
+	}
+	if req.CustomFieldsSchema.Set {
+		definition.CustomFieldsSchema = req.CustomFieldsSchema.Schema
+	}
+	if req.DefaultPoints != nil {
+		definition.DefaultPoints = *req.DefaultPoints
+	}
+	if req.EvidenceRequirements != nil {
+		definition.EvidenceRequirements = *req.EvidenceRequirements
+	}
+	if req.IsActive != nil {
+		definition.IsActive = *req.IsActive
+	}
+
+	return s.SaveAchievementType(*definition)
+}
+
+// DeactivateAchievementType stops new achievements of a type; existing ones keep it
+func (s *AchievementService) DeactivateAchievementType(name string) error {
+	definition, err := s.findAchievementType(name)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return fmt.Errorf("%w: achievement type %s does not exist", ErrAchievementNotFound, name)
+	}
+
+	definition.IsActive = false
+	_, err = s.SaveAchievementType(*definition)
+	return err
+}
+
+// validateAgainstTypes runs validateAchievement with the managed types; new achievements
+// may not use a deactivated type, while existing ones can still be edited
+func (s *AchievementService) validateAgainstTypes(achievementType, title string, details model.AchievementDetails, creating bool) error {
+	definitions, err := s.GetAchievementTypes()
+	if err != nil {
+		return err
+	}
+
+	if err := validateAchievement(definitions, achievementType, title, details, time.Now()); err != nil {
+		return err
+	}
+
+	if creating {
+		for _, definition := range definitions {
+			if definition.Name == achievementType && !definition.IsActive {
+				return &ValidationError{Fields: []model.FieldError{
+					{Field: "achievementType", Message: achievementType + " is no longer accepted for new achievements"},
+				}}
+			}
+		}
+	}
+	return nil
+}
+
+// fillTypeBuckets adds a zero count for every managed type missing from a byType breakdown
+func (s *AchievementService) fillTypeBuckets(byType map[string]int) error {
+	definitions, err := s.GetAchievementTypes()
+	if err != nil {
+		return err
+	}
+	for _, definition := range definitions {
+		if _, ok := byType[definition.Name]; !ok {
+			byType[definition.Name] = 0
+		}
+	}
+	return nil
+}
+
+// findAchievementType returns nil when the type is not defined
+func (s *AchievementService) findAchievementType(name string) (*model.AchievementTypeDefinition, error) {
+	definitions, err := s.GetAchievementTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, definition := range definitions {
+		if definition.Name == name {
+			return &definition, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"student-report/app/model"
+	"student-report/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// List Achievement Types
+func GetAchievementTypesService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	definitions, err := achievementService.GetAchievementTypes()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil tipe achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    definitions,
+		"success": true,
+	})
+}
+
+// Create Achievement Type (Admin)
+func CreateAchievementTypeService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	var req model.AchievementTypeDefinition
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	existing, err := achievementService.findAchievementType(req.Name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal membuat tipe achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	if existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Tipe achievement sudah ada",
+			"success": false,
+		})
+	}
+
+	req.IsActive = true
+	definition, err := achievementService.SaveAchievementType(req)
+	return achievementTypeResponse(c, definition, err, fiber.StatusCreated, "Tipe achievement berhasil dibuat")
+}
+
+// Update Achievement Type (Admin)
+func UpdateAchievementTypeService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	var req model.UpdateAchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	// The name is the key achievements refer to, so it cannot be renamed
+	definition, err := achievementService.UpdateAchievementType(c.Params("name"), req)
+	if errors.Is(err, ErrAchievementNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Tipe achievement tidak ditemukan",
+			"success": false,
+		})
+	}
+	return achievementTypeResponse(c, definition, err, fiber.StatusOK, "Tipe achievement berhasil diperbarui")
+}
+
+// Deactivate Achievement Type (Admin)
+func DeleteAchievementTypeService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	if err := achievementService.DeactivateAchievementType(c.Params("name")); err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal menonaktifkan tipe achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Tipe achievement berhasil dinonaktifkan",
+		"success": true,
+	})
+}
+
+func achievementTypeResponse(c *fiber.Ctx, definition *model.AchievementTypeDefinition, err error, status int, message string) error {
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Data tipe achievement tidak valid",
+				"errors":  validationErr.Fields,
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal menyimpan tipe achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"data":    definition,
+		"message": message,
+		"success": true,
+	})
+}
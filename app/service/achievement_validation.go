@@ -0,0 +1,220 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"student-report/app/model"
+	"student-report/utils"
+)
+
+// ErrValidation marks errors that carry field-level details (see ValidationError)
+var ErrValidation = errors.New("validation failed")
+
+// ValidationError lists every invalid field of a request so clients can show them all at once
+type ValidationError struct {
+	Fields []model.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.Field+": "+f.Message)
+	}
+	return ErrValidation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+var (
+	achievementTypes  = []string{"academic", "competition", "organization", "publication", "certification", "other"}
+	competitionLevels = []string{"international", "national", "regional", "local"}
+	publicationTypes  = []string{"journal", "conference", "book"}
+
+	issnPattern = regexp.MustCompile(`^\d{4}-\d{3}[\dX]$`)
+	doiPattern  = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+)
+
+// detailSection describes the AchievementDetails fields owned by one achievement type
+type detailSection struct {
+	fields   []string
+	required []string
+}
+
+var detailSections = map[string]detailSection{
+	"competition": {
+		fields:   []string{"competitionName", "competitionLevel", "rank", "medalType"},
+		required: []string{"competitionName", "competitionLevel"},
+	},
+	"publication": {
+		fields:   []string{"publicationType", "publicationTitle", "authors", "publisher", "issn", "doi"},
+		required: []string{"publicationType", "publicationTitle"},
+	},
+	"organization": {
+		fields:   []string{"organizationName", "position", "period"},
+		required: []string{"organizationName", "position"},
+	},
+	"certification": {
+		fields:   []string{"certificationName", "issuedBy", "certificationNumber", "validUntil"},
+		required: []string{"certificationName", "issuedBy"},
+	},
+}
+
+// validateAchievement checks the title, type and type-specific details of an achievement
+// against the managed type definitions: the type's required fields and custom-field schema,
+// and no fields belonging to another type.
+func validateAchievement(definitions []model.AchievementTypeDefinition, achievementType, title string, details model.AchievementDetails, now time.Time) error {
+	var fields []model.FieldError
+	add := func(field, message string) {
+		fields = append(fields, model.FieldError{Field: field, Message: message})
+	}
+
+	if strings.TrimSpace(title) == "" {
+		add("title", "is required")
+	}
+
+	var definition *model.AchievementTypeDefinition
+	names := make([]string, 0, len(definitions))
+	for i := range definitions {
+		names = append(names, definitions[i].Name)
+		if definitions[i].Name == achievementType {
+			definition = &definitions[i]
+		}
+	}
+	if achievementType == "" {
+		add("achievementType", "is required")
+	} else if definition == nil {
+		add("achievementType", "must be one of "+strings.Join(names, ", "))
+	}
+
+	present := presentDetailFields(details)
+	own := map[string]bool{}
+	for _, field := range detailSections[achievementType].fields {
+		own[field] = true
+	}
+	var required []string
+	if definition != nil {
+		required = definition.RequiredFields
+		for _, field := range required {
+			own[field] = true
+		}
+	}
+
+	for _, section := range detailSections {
+		for _, field := range section.fields {
+			if present[field] && !own[field] {
+				add("details."+field, "does not apply to "+achievementType+" achievements")
+			}
+		}
+	}
+
+	for _, field := range required {
+		if !present[field] {
+			add("details."+field, "is required for "+achievementType+" achievements")
+		}
+	}
+	if definition != nil && definition.CustomFieldsSchema != nil {
+		var customFields interface{} = details.CustomFields
+		if details.CustomFields == nil {
+			customFields = map[string]interface{}{}
+		}
+		for _, schemaErr := range utils.ValidateJSONSchema(definition.CustomFieldsSchema, customFields) {
+			field := "details.customFields"
+			if schemaErr.Path != "" {
+				field += "." + schemaErr.Path
+			}
+			add(field, schemaErr.Message)
+		}
+	}
+
+	if details.CompetitionLevel != "" && !containsValue(competitionLevels, details.CompetitionLevel) {
+		add("details.competitionLevel", "must be one of "+strings.Join(competitionLevels, ", "))
+	}
+	if details.Rank < 0 {
+		add("details.rank", "must be a positive number")
+	}
+	if details.PublicationType != "" && !containsValue(publicationTypes, details.PublicationType) {
+		add("details.publicationType", "must be one of "+strings.Join(publicationTypes, ", "))
+	}
+	if details.ISSN != "" && !validISSN(details.ISSN) {
+		add("details.issn", "must be a valid ISSN (NNNN-NNNC)")
+	}
+	if details.DOI != "" && !doiPattern.MatchString(details.DOI) {
+		add("details.doi", "must be a DOI such as 10.1000/xyz123")
+	}
+	if details.Period != nil && !details.Period.Start.Before(details.Period.End) {
+		add("details.period", "start must be before end")
+	}
+	if details.ValidUntil != nil && !details.ValidUntil.After(now) {
+		add("details.validUntil", "must be in the future")
+	}
+	if details.EventDate != "" {
+		if _, err := time.Parse("2006-01-02", details.EventDate); err != nil {
+			add("details.eventDate", "must be a date in YYYY-MM-DD format")
+		}
+	}
+	if details.Score < 0 {
+		add("details.score", "cannot be negative")
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// presentDetailFields reports which type-specific detail fields carry a value
+func presentDetailFields(d model.AchievementDetails) map[string]bool {
+	return map[string]bool{
+		"competitionName":     d.CompetitionName != "",
+		"competitionLevel":    d.CompetitionLevel != "",
+		"rank":                d.Rank != 0,
+		"medalType":           d.MedalType != "",
+		"publicationType":     d.PublicationType != "",
+		"publicationTitle":    d.PublicationTitle != "",
+		"authors":             len(d.Authors) > 0,
+		"publisher":           d.Publisher != "",
+		"issn":                d.ISSN != "",
+		"doi":                 d.DOI != "",
+		"organizationName":    d.OrganizationName != "",
+		"position":            d.Position != "",
+		"period":              d.Period != nil,
+		"certificationName":   d.CertificationName != "",
+		"issuedBy":            d.IssuedBy != "",
+		"certificationNumber": d.CertificationNumber != "",
+		"validUntil":          d.ValidUntil != nil,
+	}
+}
+
+// validISSN checks the NNNN-NNNC format and the mod-11 check digit
+func validISSN(issn string) bool {
+	issn = strings.ToUpper(issn)
+	if !issnPattern.MatchString(issn) {
+		return false
+	}
+
+	digits := strings.ReplaceAll(issn, "-", "")
+	sum := 0
+	for i := 0; i < 7; i++ {
+		sum += int(digits[i]-'0') * (8 - i)
+	}
+	check := (11 - sum%11) % 11
+
+	if check == 10 {
+		return digits[7] == 'X'
+	}
+	return int(digits[7]-'0') == check
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
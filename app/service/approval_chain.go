@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"student-report/app/model"
+)
+
+// Used for every achievement type and level without a configured chain
+var defaultApprovalStages = []model.ApprovalStage{
+	{Order: 1, Name: "advisor", Permission: "achievement:verify", AdvisorOnly: true},
+}
+
+// Get all configured approval chains
+func (s *AchievementService) GetApprovalChains() ([]model.ApprovalChain, error) {
+	return s.repo.GetApprovalChains()
+}
+
+// Replace the approval chain for an achievement type and competition level (Admin)
+func (s *AchievementService) SaveApprovalChain(chain model.ApprovalChain) error {
+	if chain.AchievementType == "" {
+		return errors.New("achievement type is required")
+	}
+	if len(chain.Stages) == 0 {
+		return errors.New("approval chain needs at least one stage")
+	}
+
+	seen := make(map[string]bool)
+	for _, stage := range chain.Stages {
+		if stage.Name == "" || stage.Permission == "" {
+			return errors.New("every stage needs a name and a permission")
+		}
+		if seen[stage.Name] {
+			return fmt.Errorf("duplicate stage name: %s", stage.Name)
+		}
+		seen[stage.Name] = true
+	}
+
+	return s.repo.SaveApprovalChain(chain)
+}
+
+// Get achievements waiting on the given approval stage that the verifier may act on
+func (s *AchievementService) GetApprovalQueue(ctx context.Context, stageName, verifierUserID string) ([]model.AchievementResponse, error) {
+	configured, err := s.repo.GetApprovalChains()
+	if err != nil {
+		return nil, err
+	}
+	chains := append(configured, model.ApprovalChain{Stages: defaultApprovalStages})
+
+	// Collect the statuses an achievement is in right before reaching this stage
+	statusSet := make(map[string]bool)
+	for _, chain := range chains {
+		for i, stage := range chain.Stages {
+			if stage.Name != stageName {
+				continue
+			}
+			if i == 0 {
+				statusSet[StatusSubmitted] = true
+			} else {
+				statusSet[ApprovalStatus(chain.Stages[i-1].Name)] = true
+			}
+		}
+	}
+
+	statuses := make([]string, 0, len(statusSet))
+	for status := range statusSet {
+		statuses = append(statuses, status)
+	}
+
+	refs, err := s.repo.GetAchievementReferencesByStatuses(statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []model.AchievementResponse{}
+	refIDs := []string{}
+	for i := range refs {
+		ref := &refs[i]
+		achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+		if err != nil {
+			continue
+		}
+
+		// Every chain is loaded above; resolve each achievement's stage from it
+		stage, err := stageAfter(chainStages(configured, achievement), ref.Status)
+		if err != nil || stage.Name != stageName {
+			continue
+		}
+
+		if s.verifierAuth != nil && s.verifierAuth.AuthorizeStage(*stage, verifierUserID, ref.StudentID) != nil {
+			continue
+		}
+
+		response := s.combineAchievementResponse(achievement, ref)
+		response.CurrentStage = stage.Name
+		results = append(results, *response)
+		refIDs = append(refIDs, ref.ID)
+	}
+	s.withCommentCounts(results, refIDs)
+
+	return results, nil
+}
+
+// approvalStages returns the chain configured for the achievement's type and competition level
+func (s *AchievementService) approvalStages(achievement *model.Achievement) ([]model.ApprovalStage, error) {
+	stages, err := s.repo.GetApprovalStages(achievement.AchievementType, achievement.Details.CompetitionLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approval chain: %w", err)
+	}
+	if len(stages) == 0 {
+		return defaultApprovalStages, nil
+	}
+	return stages, nil
+}
+
+// chainStages picks the achievement's chain out of every configured chain, falling back the way
+// GetApprovalStages does: the exact level, then the type-wide chain, then the default stages
+func chainStages(chains []model.ApprovalChain, achievement *model.Achievement) []model.ApprovalStage {
+	for _, level := range []string{achievement.Details.CompetitionLevel, ""} {
+		for _, chain := range chains {
+			if chain.AchievementType == achievement.AchievementType && chain.CompetitionLevel == level && len(chain.Stages) > 0 {
+				return chain.Stages
+			}
+		}
+	}
+	return defaultApprovalStages
+}
+
+// currentStage returns the stage that has to sign off next, or ErrInvalidTransition if nothing is pending
+func (s *AchievementService) currentStage(achievement *model.Achievement, status string) (*model.ApprovalStage, error) {
+	stages, err := s.approvalStages(achievement)
+	if err != nil {
+		return nil, err
+	}
+	return stageAfter(stages, status)
+}
+
+// stageAfter returns the stage of the chain that signs off after status
+func stageAfter(stages []model.ApprovalStage, status string) (*model.ApprovalStage, error) {
+	if !IsPendingReview(status) {
+		return nil, fmt.Errorf("%w: achievement in %s status is not awaiting review", ErrInvalidTransition, status)
+	}
+
+	if status == StatusSubmitted {
+		return &stages[0], nil
+	}
+	for i, stage := range stages {
+		if ApprovalStatus(stage.Name) == status {
+			// A chain shortened after approval finishes at its last stage
+			if i+1 >= len(stages) {
+				return &stages[len(stages)-1], nil
+			}
+			return &stages[i+1], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: status %s is not part of the approval chain", ErrInvalidTransition, status)
+}
+
+// isFinalStage reports whether approving this stage fully verifies the achievement. A chain that
+// cannot be loaded is an error, never a shortcut to verified.
+func (s *AchievementService) isFinalStage(achievement *model.Achievement, stage *model.ApprovalStage) (bool, error) {
+	stages, err := s.approvalStages(achievement)
+	if err != nil {
+		return false, err
+	}
+	return stages[len(stages)-1].Name == stage.Name, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"student-report/app/model"
+	"student-report/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Get Approval Queue for a stage (advisor, department, faculty)
+func GetApprovalQueueService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	userID := c.Locals("user_id").(string)
+
+	stage := c.Query("stage", "advisor")
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := newVerifierAchievementService(c, db, achievementRepo)
+
+	ctx := context.Background()
+	achievements, err := achievementService.GetApprovalQueue(ctx, stage, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil antrian persetujuan",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    achievements,
+		"stage":   stage,
+		"total":   len(achievements),
+		"success": true,
+	})
+}
+
+// Get Approval Chains (Admin)
+func GetApprovalChainsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	chains, err := achievementService.GetApprovalChains()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil konfigurasi approval chain",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":         chains,
+		"defaultChain": defaultApprovalStages,
+		"success":      true,
+	})
+}
+
+// Save Approval Chain for a type and competition level (Admin)
+func SaveApprovalChainService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	var req model.ApprovalChain
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	if err := achievementService.SaveApprovalChain(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Gagal menyimpan approval chain",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    req,
+		"message": "Approval chain berhasil disimpan",
+		"success": true,
+	})
+}
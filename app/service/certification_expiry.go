@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report/app/model"
+)
+
+// How often the expiry job looks for achievements past their validUntil
+const certificationExpiryInterval = time.Hour
+
+// Default and maximum look-ahead of the expiring achievements list
+const (
+	defaultExpiringWithin = 30 * 24 * time.Hour
+	maxExpiringWithin     = 366 * 24 * time.Hour
+)
+
+// expiredCertificationWeight reads EXPIRED_CERTIFICATION_WEIGHT, the percent of an expired
+// achievement's points that still counts; the default 0 excludes expired achievements
+func expiredCertificationWeight() int {
+	if weight, err := strconv.Atoi(os.Getenv("EXPIRED_CERTIFICATION_WEIGHT")); err == nil && weight >= 0 && weight <= 100 {
+		return weight
+	}
+	return 0
+}
+
+// SetExpiredCertificationWeight overrides the percent of expired points that still counts
+func (s *AchievementService) SetExpiredCertificationWeight(percent int) {
+	if percent >= 0 && percent <= 100 {
+		s.expiredWeight = percent
+	}
+}
+
+// MarkExpiredCertifications flags certifications whose validity ended at or before now; returns how many
+func (s *AchievementService) MarkExpiredCertifications(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.MarkExpiredAchievementsMongo(ctx, now)
+}
+
+// RunCertificationExpiryJob marks expired certifications now and then every interval until ctx is done
+func (s *AchievementService) RunCertificationExpiryJob(ctx context.Context) {
+	ticker := time.NewTicker(certificationExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		if marked, err := s.MarkExpiredCertifications(ctx, time.Now()); err != nil {
+			log.Printf("certification expiry job failed: %v", err)
+		} else if marked > 0 {
+			log.Printf("certification expiry job marked %d achievements as expired", marked)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get achievements that expire within the given window, soonest first; nil studentIDs covers everyone
+func (s *AchievementService) GetExpiringAchievements(ctx context.Context, studentIDs []string, within time.Duration) ([]model.ExpiringAchievement, error) {
+	now := time.Now()
+	achievements, err := s.repo.GetExpiringAchievementsMongo(ctx, studentIDs, now, now.Add(within))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []model.ExpiringAchievement{}
+	for i := range achievements {
+		achievement := &achievements[i]
+		refs, err := s.repo.GetAchievementReferencesByMongoID(achievement.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+
+		// Each team member in scope is reminded about their own reference
+		for j := range refs {
+			ref := &refs[j]
+			if ref.Status == StatusDeleted || (studentIDs != nil && !containsValue(studentIDs, ref.StudentID)) {
+				continue
+			}
+			validUntil := *achievement.Details.ValidUntil
+			results = append(results, model.ExpiringAchievement{
+				AchievementResponse: *s.combineAchievementResponse(achievement, ref),
+				ValidUntil:          validUntil,
+				DaysLeft:            int(validUntil.Sub(now).Hours() / 24),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// ParseExpiringWithin reads a look-ahead such as "30d" or "72h"; empty means 30 days
+func ParseExpiringWithin(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultExpiringWithin, nil
+	}
+
+	var within time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errors.New("within must be a number of days such as 30d")
+		}
+		within = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if within, err = time.ParseDuration(value); err != nil {
+			return 0, errors.New("within must be a number of days such as 30d")
+		}
+	}
+
+	if within <= 0 || within > maxExpiringWithin {
+		return 0, errors.New("within must be between 1h and 366d")
+	}
+	return within, nil
+}
+
+// applyExpiryPolicy counts expired verified points at the configured weight
+func (s *AchievementService) applyExpiryPolicy(breakdown *model.PointsBreakdown) {
+	breakdown.VerifiedPoints -= breakdown.ExpiredPoints - s.expiredPoints(breakdown.ExpiredPoints)
+}
+
+// countedAchievement is a verified achievement with its points at the expiry weight once it has expired
+func (s *AchievementService) countedAchievement(achievement model.Achievement) model.Achievement {
+	if achievement.ExpiredAt != nil {
+		achievement.Points = s.expiredPoints(achievement.Points)
+	}
+	return achievement
+}
+
+// expiredPoints is what an expired achievement's points are still worth
+func (s *AchievementService) expiredPoints(points int) int {
+	return points * s.expiredWeight / 100
+}
@@ -1,17 +1,20 @@
 package service
 
 import (
-	// "database/sql"
+	"database/sql"
+	"errors"
 	"os"
+	"student-report/app/model"
 	"student-report/app/repository"
+	"time"
 	"github.com/gofiber/fiber/v2"
 )
 
 type LecturerService struct {
-	repo *repository.LecturerRepository
+	repo repository.ILecturerRepository
 }
 
-func NewLecturerService(repo *repository.LecturerRepository) *LecturerService {
+func NewLecturerService(repo repository.ILecturerRepository) *LecturerService {
 	return &LecturerService{repo: repo}
 }
 
@@ -50,4 +53,283 @@ func (s *LecturerService) GetLecturersService(c *fiber.Ctx) error {
 		"message": "Berhasil mendapatkan data Students",
 		"success": true,
 	})
-}
\ No newline at end of file
+}
+
+// GrantVerificationOverride godoc
+// @Summary Grant verification override
+// @Description Allow a lecturer (e.g. department head) to verify achievements of all students advised within their department (Admin only)
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Param key path string true "API Key"
+// @Param id path string true "Lecturer ID"
+// @Security BearerAuth
+// @Success 200 {object} object{success=bool,message=string} "Override granted successfully"
+// @Failure 401 {object} object{success=bool,message=string} "Unauthorized"
+// @Failure 403 {object} object{success=bool,message=string} "Forbidden - admin only"
+// @Failure 500 {object} object{success=bool,message=string,error=string} "Internal server error"
+// @Router /{key}/v1/lecturers/{id}/verification-override [post]
+func (s *LecturerService) GrantVerificationOverrideService(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key != os.Getenv("API_KEY") {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "API tidak sesuai",
+			"success": false,
+		})
+	}
+
+	lecturerID := c.Params("id")
+	adminID := c.Locals("user_id").(string)
+
+	if err := s.repo.GrantVerificationOverride(lecturerID, adminID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal memberikan override verifikasi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Override verifikasi berhasil diberikan",
+		"success": true,
+	})
+}
+
+// RevokeVerificationOverride godoc
+// @Summary Revoke verification override
+// @Description Restrict a lecturer back to verifying only their own advisees (Admin only)
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Param key path string true "API Key"
+// @Param id path string true "Lecturer ID"
+// @Security BearerAuth
+// @Success 200 {object} object{success=bool,message=string} "Override revoked successfully"
+// @Failure 401 {object} object{success=bool,message=string} "Unauthorized"
+// @Failure 403 {object} object{success=bool,message=string} "Forbidden - admin only"
+// @Failure 404 {object} object{success=bool,message=string} "Override not found"
+// @Failure 500 {object} object{success=bool,message=string,error=string} "Internal server error"
+// @Router /{key}/v1/lecturers/{id}/verification-override [delete]
+func (s *LecturerService) RevokeVerificationOverrideService(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key != os.Getenv("API_KEY") {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "API tidak sesuai",
+			"success": false,
+		})
+	}
+
+	lecturerID := c.Params("id")
+	if err := s.repo.RevokeVerificationOverride(lecturerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Override verifikasi tidak ditemukan",
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mencabut override verifikasi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Override verifikasi berhasil dicabut",
+		"success": true,
+	})
+}
+
+// GetDelegations godoc
+// @Summary Get verification delegations
+// @Description List the delegations a lecturer handed out while on leave (Admin or the lecturer)
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Param key path string true "API Key"
+// @Param id path string true "Delegator Lecturer ID"
+// @Security BearerAuth
+// @Success 200 {object} object{success=bool,data=[]model.LecturerDelegation} "Delegations retrieved successfully"
+// @Failure 401 {object} object{success=bool,message=string} "Unauthorized"
+// @Failure 403 {object} object{success=bool,message=string} "Forbidden"
+// @Failure 500 {object} object{success=bool,message=string,error=string} "Internal server error"
+// @Router /{key}/v1/lecturers/{id}/delegations [get]
+func (s *LecturerService) GetDelegationsService(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key != os.Getenv("API_KEY") {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "API tidak sesuai",
+			"success": false,
+		})
+	}
+
+	lecturerID := c.Params("id")
+	if !s.canManageDelegations(c, lecturerID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Hanya admin atau dosen yang bersangkutan yang dapat mengelola delegasi",
+			"success": false,
+		})
+	}
+
+	delegations, err := s.repo.GetDelegations(lecturerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil data delegasi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    delegations,
+		"success": true,
+	})
+}
+
+// CreateDelegation godoc
+// @Summary Delegate verification duties
+// @Description Let another lecturer verify this lecturer's advisees for a date range (Admin or the lecturer)
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Param key path string true "API Key"
+// @Param id path string true "Delegator Lecturer ID"
+// @Param request body model.CreateDelegationRequest true "Delegate and date range"
+// @Security BearerAuth
+// @Success 201 {object} object{success=bool,message=string,data=model.LecturerDelegation} "Delegation created successfully"
+// @Failure 400 {object} object{success=bool,message=string} "Invalid request"
+// @Failure 401 {object} object{success=bool,message=string} "Unauthorized"
+// @Failure 403 {object} object{success=bool,message=string} "Forbidden"
+// @Failure 500 {object} object{success=bool,message=string,error=string} "Internal server error"
+// @Router /{key}/v1/lecturers/{id}/delegations [post]
+func (s *LecturerService) CreateDelegationService(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key != os.Getenv("API_KEY") {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "API tidak sesuai",
+			"success": false,
+		})
+	}
+
+	lecturerID := c.Params("id")
+	if !s.canManageDelegations(c, lecturerID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Hanya admin atau dosen yang bersangkutan yang dapat mengelola delegasi",
+			"success": false,
+		})
+	}
+
+	var req model.CreateDelegationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	startDate, errStart := time.Parse("2006-01-02", req.StartDate)
+	endDate, errEnd := time.Parse("2006-01-02", req.EndDate)
+	if errStart != nil || errEnd != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Format tanggal harus YYYY-MM-DD",
+			"success": false,
+		})
+	}
+	if endDate.Before(startDate) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Tanggal selesai tidak boleh sebelum tanggal mulai",
+			"success": false,
+		})
+	}
+	if req.DelegateID == "" || req.DelegateID == lecturerID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Delegate harus dosen lain",
+			"success": false,
+		})
+	}
+
+	delegation := &model.LecturerDelegation{
+		DelegatorID: lecturerID,
+		DelegateID:  req.DelegateID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		CreatedBy:   c.Locals("user_id").(string),
+	}
+	if err := s.repo.CreateDelegation(delegation); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal membuat delegasi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data":    delegation,
+		"message": "Delegasi verifikasi berhasil dibuat",
+		"success": true,
+	})
+}
+
+// DeleteDelegation godoc
+// @Summary Cancel a verification delegation
+// @Description End a delegation early (Admin or the lecturer)
+// @Tags Lecturers
+// @Accept json
+// @Produce json
+// @Param key path string true "API Key"
+// @Param id path string true "Delegator Lecturer ID"
+// @Param delegationId path string true "Delegation ID"
+// @Security BearerAuth
+// @Success 200 {object} object{success=bool,message=string} "Delegation deleted successfully"
+// @Failure 401 {object} object{success=bool,message=string} "Unauthorized"
+// @Failure 403 {object} object{success=bool,message=string} "Forbidden"
+// @Failure 404 {object} object{success=bool,message=string} "Delegation not found"
+// @Failure 500 {object} object{success=bool,message=string,error=string} "Internal server error"
+// @Router /{key}/v1/lecturers/{id}/delegations/{delegationId} [delete]
+func (s *LecturerService) DeleteDelegationService(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key != os.Getenv("API_KEY") {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "API tidak sesuai",
+			"success": false,
+		})
+	}
+
+	lecturerID := c.Params("id")
+	if !s.canManageDelegations(c, lecturerID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Hanya admin atau dosen yang bersangkutan yang dapat mengelola delegasi",
+			"success": false,
+		})
+	}
+
+	if err := s.repo.DeleteDelegation(lecturerID, c.Params("delegationId")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Delegasi tidak ditemukan",
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal menghapus delegasi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Delegasi berhasil dihapus",
+		"success": true,
+	})
+}
+
+// canManageDelegations allows admins and the delegating lecturer themselves
+func (s *LecturerService) canManageDelegations(c *fiber.Ctx, lecturerID string) bool {
+	if role, _ := c.Locals("role").(string); role == RoleAdmin {
+		return true
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	ownID, err := s.repo.GetLecturerIDByUserID(userID)
+	return err == nil && ownID == lecturerID
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"student-report/app/model"
+	"student-report/app/points"
+)
+
+// Get the rule table currently used for scoring (the built-in defaults until an admin saves one)
+func (s *AchievementService) GetPointsRuleSet() (*model.PointsRuleSet, error) {
+	ruleSet, err := s.repo.GetPointsRuleSet()
+	if err != nil {
+		return nil, err
+	}
+	if ruleSet.Version == 0 {
+		defaults := points.DefaultRuleSet()
+		return &defaults, nil
+	}
+	return ruleSet, nil
+}
+
+// Save a new version of the points rule table, period caps and team policy (Admin)
+func (s *AchievementService) SavePointsRuleSet(rules []model.PointsRule, caps []model.PointsCap, teamPolicy string, adminID string) (*model.PointsRuleSet, error) {
+	if err := points.Validate(rules); err != nil {
+		return nil, err
+	}
+	if err := points.ValidateCaps(caps); err != nil {
+		return nil, err
+	}
+	if err := points.ValidateTeamPolicy(teamPolicy); err != nil {
+		return nil, err
+	}
+	if teamPolicy == "" {
+		teamPolicy = points.TeamPolicyDuplicate
+	}
+	return s.repo.SavePointsRuleSet(rules, caps, teamPolicy, adminID)
+}
+
+// Rescore every achievement with the current rule table; returns how many changed
+func (s *AchievementService) RecomputeAllPoints(ctx context.Context) (int, error) {
+	engine, err := s.pointsEngine()
+	if err != nil {
+		return 0, err
+	}
+
+	definitions, err := s.GetAchievementTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	refs, err := s.repo.GetAllAchievementReferences()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	rescored := make(map[string]bool)
+	for _, ref := range refs {
+		// Team members share one document, which only needs rescoring once
+		if rescored[ref.MongoAchievementID] {
+			continue
+		}
+		rescored[ref.MongoAchievementID] = true
+
+		achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+		if err != nil {
+			continue
+		}
+
+		result, members := scoreAchievement(engine, definitions, achievement)
+		if result.Points == achievement.Points && result.RuleID == achievement.PointsRuleID && result.Version == achievement.PointsVersion &&
+			reflect.DeepEqual(members, achievement.TeamMembers) {
+			continue
+		}
+
+		if err := s.repo.UpdateAchievementPointsMongo(ctx, ref.MongoAchievementID, result.Points, result.RuleID, result.Version, members); err != nil {
+			return updated, fmt.Errorf("failed to rescore achievement %s: %w", ref.ID, err)
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// applyPoints overwrites the achievement's points with the engine's result
+func (s *AchievementService) applyPoints(achievement *model.Achievement) error {
+	engine, err := s.pointsEngine()
+	if err != nil {
+		return err
+	}
+	definitions, err := s.GetAchievementTypes()
+	if err != nil {
+		return err
+	}
+
+	result, members := scoreAchievement(engine, definitions, achievement)
+	achievement.Points = result.Points
+	achievement.PointsRuleID = result.RuleID
+	achievement.PointsVersion = result.Version
+	achievement.TeamMembers = members
+	return nil
+}
+
+// scoreAchievement scores an achievement with the rule table, falling back to its type's
+// default points when no rule matches, and splits the result across the team
+func scoreAchievement(engine *points.Engine, definitions []model.AchievementTypeDefinition, achievement *model.Achievement) (points.Result, []model.TeamMember) {
+	result := engine.Calculate(achievement)
+	if result.RuleID == "" {
+		for _, definition := range definitions {
+			if definition.Name == achievement.AchievementType && definition.DefaultPoints > 0 {
+				result.Points = definition.DefaultPoints
+				result.RuleID = "type-default:" + definition.Name
+			}
+		}
+	}
+	return result, teamShares(engine, achievement.TeamMembers, result.Points)
+}
+
+// teamShares returns a copy of the team members with each member's share of the points
+func teamShares(engine *points.Engine, members []model.TeamMember, total int) []model.TeamMember {
+	if len(members) == 0 {
+		return nil
+	}
+	shares := engine.TeamShares(total, len(members))
+	result := make([]model.TeamMember, len(members))
+	for i, member := range members {
+		member.Points = shares[i]
+		result[i] = member
+	}
+	return result
+}
+
+// applyPointCaps sets the report's capped total and lists the verified achievements a period cap clipped
+func (s *AchievementService) applyPointCaps(report *model.StudentReportResponse, verified []model.Achievement) error {
+	engine, periods, err := s.pointCaps()
+	if err != nil {
+		return err
+	}
+	results := engine.ApplyCaps(verified, periods)
+
+	titles := make(map[string]model.Achievement)
+	for _, a := range verified {
+		titles[a.ID.Hex()] = a
+	}
+
+	report.TotalPoints = 0
+	report.Clipped = []model.ClippedAchievement{}
+	for _, result := range results {
+		report.TotalPoints += result.Points
+		if !result.Clipped {
+			continue
+		}
+		a := titles[result.AchievementID]
+		report.Clipped = append(report.Clipped, model.ClippedAchievement{
+			AchievementID:   result.AchievementID,
+			Title:           a.Title,
+			AchievementType: a.AchievementType,
+			Period:          result.Period,
+			RawPoints:       result.RawPoints,
+			CountedPoints:   result.Points,
+		})
+	}
+	return nil
+}
+
+// capStudentPoints sets each student's total to their verified points after the expiry weight and
+// the period caps, the same total the student's own report shows; engine and periods come from pointCaps
+func (s *AchievementService) capStudentPoints(students []model.StudentAchievementCount, engine *points.Engine, periods []model.AcademicPeriod) {
+	for i := range students {
+		student := &students[i]
+		s.applyExpiryPolicy(&student.PointsBreakdown)
+
+		counted := make([]model.Achievement, len(student.Verified))
+		for j, a := range student.Verified {
+			counted[j] = s.countedAchievement(a)
+		}
+		student.TotalPoints = 0
+		for _, result := range engine.ApplyCaps(counted, periods) {
+			student.TotalPoints += result.Points
+		}
+	}
+}
+
+// rankStudents orders students with verified achievements by their capped total and keeps the first limit
+func (s *AchievementService) rankStudents(students []model.StudentAchievementCount, limit int) []model.StudentAchievementCount {
+	ranked := []model.StudentAchievementCount{}
+	for _, student := range students {
+		if student.Count > 0 {
+			ranked = append(ranked, student)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].TotalPoints != ranked[j].TotalPoints {
+			return ranked[i].TotalPoints > ranked[j].TotalPoints
+		}
+		return ranked[i].Count > ranked[j].Count
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	for i := range ranked {
+		ranked[i].StudentName, ranked[i].NIM = s.repo.GetStudentInfo(ranked[i].StudentID)
+	}
+	return ranked
+}
+
+// pointCaps loads the current rule set and the academic periods its caps are applied over;
+// load them once per request and reuse them for every student
+func (s *AchievementService) pointCaps() (*points.Engine, []model.AcademicPeriod, error) {
+	engine, err := s.pointsEngine()
+	if err != nil {
+		return nil, nil, err
+	}
+	periods, err := s.repo.GetAcademicPeriods()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load academic periods: %w", err)
+	}
+	return engine, periods, nil
+}
+
+func (s *AchievementService) pointsEngine() (*points.Engine, error) {
+	ruleSet, err := s.GetPointsRuleSet()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load points rules: %w", err)
+	}
+	return points.NewEngine(*ruleSet), nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"student-report/app/model"
+	"student-report/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Get Current Points Rule Table (Admin)
+func GetPointsRulesService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ruleSet, err := achievementService.GetPointsRuleSet()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil aturan poin",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    ruleSet,
+		"success": true,
+	})
+}
+
+// Save Points Rule Table as a New Version (Admin)
+func SavePointsRulesService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	userID := c.Locals("user_id").(string)
+
+	var req model.SavePointsRulesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"success": false,
+		})
+	}
+
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ruleSet, err := achievementService.SavePointsRuleSet(req.Rules, req.Caps, req.TeamPolicy, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Gagal menyimpan aturan poin",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    ruleSet,
+		"message": "Aturan poin berhasil disimpan",
+		"success": true,
+	})
+}
+
+// Recompute Points of All Achievements with the Current Rules (Admin)
+func RecomputePointsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+
+	ctx := context.Background()
+	updated, err := achievementService.RecomputeAllPoints(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal menghitung ulang poin",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"updated": updated,
+		"message": "Poin achievement berhasil dihitung ulang",
+		"success": true,
+	})
+}
@@ -1,12 +1,21 @@
 package service
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"errors"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"student-report/app/model"
 	"student-report/app/repository"
+	"student-report/app/transcript"
+	"student-report/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,46 +23,21 @@ import (
 
 // FR-011: Get Achievement Statistics
 func GetStatisticsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
-	role := c.Locals("role").(string)
-	userID := c.Locals("user_id").(string)
-	
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 	
 	ctx := context.Background()
-	var studentIDs []string
+	studentIDs, ok, err := reportScope(c, db)
+	if !ok {
+		return err
+	}
 	
-	// Filter based on role
-	if role == "student" {
-		// Get own statistics
-		studentRepo := repository.NewStudentRepository(db)
-		student, err := studentRepo.GetStudentByUserID(userID)
-		if err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"message": "Student profile tidak ditemukan",
-				"success": false,
-			})
-		}
-		studentIDs = []string{student.ID}
-	} else if role == "lecturer" {
-		// Get advisees statistics
-		studentRepo := repository.NewStudentRepository(db)
-		advisees, err := studentRepo.GetStudentByAdvisorID(userID)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"message": "Gagal mengambil data mahasiswa bimbingan",
-				"error":   err.Error(),
-				"success": false,
-			})
-		}
-		
-		for _, advisee := range advisees {
-			studentIDs = append(studentIDs, advisee.ID)
-		}
+	period, err := achievementService.ResolveAcademicPeriod(c.Query("period"))
+	if err != nil {
+		return periodErrorResponse(c, err)
 	}
-	// else admin: leave studentIDs empty to get all statistics
 	
-	stats, err := achievementService.GetStatistics(ctx, studentIDs)
+	stats, err := achievementService.GetStatisticsForPeriod(ctx, studentIDs, period)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal mengambil statistik",
@@ -68,6 +52,39 @@ func GetStatisticsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) err
 	})
 }
 
+// Get Achievement Trends (role-based, same scope as the statistics)
+func GetTrendsService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+	
+	ctx := context.Background()
+	studentIDs, ok, err := reportScope(c, db)
+	if !ok {
+		return err
+	}
+	trends, err := achievementService.GetTrends(ctx, studentIDs, c.Query("granularity"), c.Query("groupBy"))
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"message": "Parameter tren tidak valid",
+				"errors":  validationErr.Fields,
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil tren achievement",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":    trends,
+		"success": true,
+	})
+}
+
 // Get Student Report
 func GetStudentReportService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	studentID := c.Params("id")
@@ -81,8 +98,13 @@ func GetStudentReportService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 	
+	period, err := achievementService.ResolveAcademicPeriod(c.Query("period"))
+	if err != nil {
+		return periodErrorResponse(c, err)
+	}
+	
 	ctx := context.Background()
-	report, err := achievementService.GetStudentReport(ctx, studentID)
+	report, err := achievementService.GetStudentReportForPeriod(ctx, studentID, period)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal mengambil report mahasiswa",
@@ -97,6 +119,45 @@ func GetStudentReportService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database)
 	})
 }
 
+// Get Student Transcript (SKPI PDF of verified achievements, with a new document number each time)
+func GetStudentTranscriptService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
+	studentID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	
+	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
+	achievementService := NewAchievementService(achievementRepo)
+	
+	// Students get their own transcript, lecturers their advisees'
+	allowedStudentIDs, ok, err := reportScope(c, db)
+	if !ok {
+		return err
+	}
+	
+	period, err := achievementService.ResolveAcademicPeriod(c.Query("period"))
+	if err != nil {
+		return periodErrorResponse(c, err)
+	}
+	
+	// The number is only kept once the PDF has been rendered
+	ctx := context.Background()
+	var buf bytes.Buffer
+	issued, err := achievementService.IssueTranscript(ctx, studentID, period, userID, allowedStudentIDs, func(issued *model.Transcript) error {
+		return transcript.RenderPDF(&buf, issued)
+	})
+	if err != nil {
+		return c.Status(achievementErrorStatus(err)).JSON(fiber.Map{
+			"message": "Gagal membuat transkrip prestasi",
+			"error":   err.Error(),
+			"success": false,
+		})
+	}
+	
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set("X-Document-Number", issued.Document.Number)
+	c.Attachment(strings.ReplaceAll(issued.Document.Number, "/", "-") + ".pdf")
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
 // Get All Achievements with Filter (Admin)
 func GetAllAchievementsWithFilterService(c *fiber.Ctx, db *sql.DB, mongoDB *mongo.Database) error {
 	// Parse query parameters
@@ -125,6 +186,10 @@ func GetAllAchievementsWithFilterService(c *fiber.Ctx, db *sql.DB, mongoDB *mong
 		filter.DateTo = &dateTo
 	}
 	
+	if period := c.Query("period"); period != "" {
+		filter.Period = &period
+	}
+	
 	if sortBy := c.Query("sortBy"); sortBy != "" {
 		filter.SortBy = &sortBy
 	}
@@ -148,6 +213,19 @@ func GetAllAchievementsWithFilterService(c *fiber.Ctx, db *sql.DB, mongoDB *mong
 	achievementRepo := repository.NewAchievementRepository(mongoDB, db)
 	achievementService := NewAchievementService(achievementRepo)
 	
+	if filter.Period != nil {
+		period, err := achievementService.ResolveAcademicPeriod(*filter.Period)
+		if err != nil {
+			return periodErrorResponse(c, err)
+		}
+		filter.AcademicPeriod = period
+	}
+	
+	// Spreadsheet export of every matching row (?format=csv|xlsx)
+	if format := c.Query("format"); format != "" {
+		return exportAchievementsResponse(c, achievementService, filter, format)
+	}
+	
 	ctx := context.Background()
 	achievements, total, err := achievementService.GetAllAchievementsWithFilter(ctx, filter)
 	if err != nil {
@@ -167,3 +245,108 @@ func GetAllAchievementsWithFilterService(c *fiber.Ctx, db *sql.DB, mongoDB *mong
 		"success":    true,
 	})
 }
+
+// exportAchievementsResponse streams the filtered achievements as a CSV or XLSX download. Rows are
+// written as they are read, so a failure part way through can only be logged.
+func exportAchievementsResponse(c *fiber.Ctx, achievementService *AchievementService, filter model.AchievementFilter, format string) error {
+	filename := "achievements-" + time.Now().Format("20060102")
+	switch format {
+	case "csv":
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	case "xlsx":
+		c.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Format export tidak valid",
+			"error":   "format must be csv or xlsx",
+			"success": false,
+		})
+	}
+	c.Attachment(filename + "." + format)
+	
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx := context.Background()
+		var err error
+		if format == "csv" {
+			writer := csv.NewWriter(w)
+			if err = writer.Write(AchievementExportColumns); err == nil {
+				err = achievementService.ExportAchievements(ctx, filter, writer.Write)
+			}
+			writer.Flush()
+			if err == nil {
+				err = writer.Error()
+			}
+		} else {
+			var writer *utils.XLSXWriter
+			if writer, err = utils.NewXLSXWriter(w, "Achievements"); err == nil {
+				if err = writer.WriteHeader(AchievementExportColumns); err == nil {
+					err = achievementService.ExportAchievements(ctx, filter, writer.Write)
+				}
+				if closeErr := writer.Close(); err == nil {
+					err = closeErr
+				}
+			}
+		}
+		if err != nil {
+			log.Printf("achievement export failed: %v", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// periodErrorResponse reports an unknown ?period= code
+func periodErrorResponse(c *fiber.Ctx, err error) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Periode akademik tidak valid",
+			"errors":  validationErr.Fields,
+			"success": false,
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"message": "Gagal mengambil periode akademik",
+		"error":   err.Error(),
+		"success": false,
+	})
+}
+
+// reportScope limits students to their own achievements and lecturers to their advisees (including
+// delegated ones); admins see everyone (nil). A lecturer without advisees gets an empty, non-nil
+// scope. When ok is false the error response has already been sent.
+func reportScope(c *fiber.Ctx, db *sql.DB) (studentIDs []string, ok bool, err error) {
+	role := c.Locals("role").(string)
+	userID := c.Locals("user_id").(string)
+	
+	switch role {
+	case RoleStudent:
+		student, err := repository.NewStudentRepository(db).GetStudentByUserID(userID)
+		if err != nil {
+			return nil, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Student profile tidak ditemukan",
+				"success": false,
+			})
+		}
+		return []string{student.ID}, true, nil
+	case RoleLecturer:
+		lecturerRepo := repository.NewLecturerRepository(db)
+		lecturerID, err := lecturerRepo.GetLecturerIDByUserID(userID)
+		if err != nil {
+			return nil, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"message": "Lecturer profile tidak ditemukan",
+				"success": false,
+			})
+		}
+		studentIDs, err = adviseeIDsWithDelegations(db, lecturerRepo, lecturerID)
+		if err != nil {
+			return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Gagal mengambil data mahasiswa bimbingan",
+				"error":   err.Error(),
+				"success": false,
+			})
+		}
+		return studentIDs, true, nil
+	}
+	return nil, true, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"student-report/app/model"
+)
+
+// IssueTranscript prepares an achievement transcript (SKPI attachment) of the student for the
+// period (nil for all time) and records it under a new document number. Only verified
+// achievements appear on the transcript; the report is returned whole for the renderer.
+// render is handed the numbered transcript before the record is committed, so a transcript
+// that fails to render is never recorded as issued; nil only records it.
+// allowedStudentIDs limits whose transcript the caller may issue; nil means no restriction.
+func (s *AchievementService) IssueTranscript(ctx context.Context, studentID string, period *model.AcademicPeriod, issuedBy string, allowedStudentIDs []string, render func(*model.Transcript) error) (*model.Transcript, error) {
+	exists, err := s.repo.StudentExists(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: student %s does not exist", ErrAchievementNotFound, studentID)
+	}
+	if allowedStudentIDs != nil && !containsValue(allowedStudentIDs, studentID) {
+		return nil, fmt.Errorf("%w: you cannot issue a transcript for this student", ErrUnauthorized)
+	}
+
+	report, err := s.GetStudentReportForPeriod(ctx, studentID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	verified := 0
+	var verifierIDs []string
+	for _, achievement := range report.Achievements {
+		if achievement.Status != StatusVerified {
+			continue
+		}
+		verified++
+		if achievement.VerifiedBy != nil && !containsValue(verifierIDs, *achievement.VerifiedBy) {
+			verifierIDs = append(verifierIDs, *achievement.VerifiedBy)
+		}
+	}
+
+	verifierNames, err := s.repo.GetUserNames(verifierIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := &model.Transcript{
+		Document: model.TranscriptDocument{
+			StudentID:            studentID,
+			Period:               report.Period,
+			VerifiedAchievements: verified,
+			TotalPoints:          report.TotalPoints,
+			IssuedBy:             issuedBy,
+		},
+		Report:        report,
+		VerifierNames: verifierNames,
+	}
+
+	var renderErr error
+	err = s.repo.CreateTranscriptDocument(&issued.Document, func() error {
+		if render != nil {
+			renderErr = render(issued)
+		}
+		return renderErr
+	})
+	if renderErr != nil {
+		return nil, fmt.Errorf("failed to render transcript: %w", renderErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record transcript: %w", err)
+	}
+	return issued, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"student-report/app/model"
+	"student-report/app/repository"
+)
+
+// VerificationAuthorizer decides whether a verifier may sign off an approval stage of a student's achievement
+type VerificationAuthorizer interface {
+	AuthorizeStage(stage model.ApprovalStage, verifierUserID, studentID string) error
+}
+
+// AdvisorAuthorizer restricts advisor stages to the student's academic advisor (plus lecturers
+// the advisor currently delegates to and lecturers an admin granted a department-wide override)
+// and later stages to holders of the stage permission
+type AdvisorAuthorizer struct {
+	studentRepo  repository.IStudentRepository
+	lecturerRepo repository.ILecturerRepository
+	permissions  []string
+}
+
+func NewAdvisorAuthorizer(studentRepo repository.IStudentRepository, lecturerRepo repository.ILecturerRepository, permissions []string) *AdvisorAuthorizer {
+	return &AdvisorAuthorizer{
+		studentRepo:  studentRepo,
+		lecturerRepo: lecturerRepo,
+		permissions:  permissions,
+	}
+}
+
+func (a *AdvisorAuthorizer) AuthorizeStage(stage model.ApprovalStage, verifierUserID, studentID string) error {
+	if !hasPermission(a.permissions, stage.Permission) {
+		return fmt.Errorf("%w: the %s approval stage requires permission %s", ErrUnauthorized, stage.Name, stage.Permission)
+	}
+
+	if stage.AdvisorOnly {
+		return a.authorizeAdvisor(verifierUserID, studentID)
+	}
+	return nil
+}
+
+func (a *AdvisorAuthorizer) authorizeAdvisor(verifierUserID, studentID string) error {
+	lecturerID, err := a.lecturerRepo.GetLecturerIDByUserID(verifierUserID)
+	if err != nil {
+		return fmt.Errorf("%w: lecturer profile not found for verifier", ErrUnauthorized)
+	}
+
+	advisees, err := a.studentRepo.GetStudentByAdvisorID(lecturerID)
+	if err != nil {
+		return fmt.Errorf("failed to load advisees: %w", err)
+	}
+	for _, advisee := range advisees {
+		if advisee.ID == studentID {
+			return nil
+		}
+	}
+
+	delegated, err := a.isDelegatedAdvisee(lecturerID, studentID)
+	if err != nil {
+		return err
+	}
+	if delegated {
+		return nil
+	}
+
+	hasOverride, err := a.lecturerRepo.HasVerificationOverride(lecturerID)
+	if err != nil {
+		return fmt.Errorf("failed to check verification override: %w", err)
+	}
+	if hasOverride {
+		sameDepartment, err := a.lecturerRepo.SharesDepartmentWithAdvisor(lecturerID, studentID)
+		if err != nil {
+			return fmt.Errorf("failed to check advisor department: %w", err)
+		}
+		if sameDepartment {
+			return nil
+		}
+		return fmt.Errorf("%w: verification override only covers students advised within your department", ErrUnauthorized)
+	}
+
+	return fmt.Errorf("%w: only the student's academic advisor can verify or reject this achievement", ErrUnauthorized)
+}
+
+// isDelegatedAdvisee reports whether the student's advisor delegated verification to the lecturer today
+func (a *AdvisorAuthorizer) isDelegatedAdvisee(lecturerID, studentID string) (bool, error) {
+	delegatorIDs, err := a.lecturerRepo.GetActiveDelegatorIDs(lecturerID, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to check delegations: %w", err)
+	}
+
+	for _, delegatorID := range delegatorIDs {
+		advisees, err := a.studentRepo.GetStudentByAdvisorID(delegatorID)
+		if err != nil {
+			return false, fmt.Errorf("failed to load delegated advisees: %w", err)
+		}
+		for _, advisee := range advisees {
+			if advisee.ID == studentID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func hasPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"student-report/app/model"
+)
+
+// Days a submission may wait for review before it is reported as overdue
+const defaultVerificationSLADays = 14
+
+// verificationSLADays reads VERIFICATION_SLA_DAYS, falling back to the default
+func verificationSLADays() int {
+	if days, err := strconv.Atoi(os.Getenv("VERIFICATION_SLA_DAYS")); err == nil && days > 0 {
+		return days
+	}
+	return defaultVerificationSLADays
+}
+
+// SetVerificationSLADays overrides the SLA used for overdue tracking
+func (s *AchievementService) SetVerificationSLADays(days int) {
+	if days > 0 {
+		s.slaDays = days
+	}
+}
+
+// Get achievements awaiting review longer than the SLA, oldest first; empty studentIDs covers everyone
+func (s *AchievementService) GetOverdueAchievements(ctx context.Context, studentIDs []string) ([]model.OverdueAchievement, error) {
+	now := time.Now()
+	refs, err := s.repo.GetOverdueAchievementReferences(studentIDs, s.slaCutoff(now))
+	if err != nil {
+		return nil, err
+	}
+
+	sla := time.Duration(s.slaDays) * 24 * time.Hour
+	results := []model.OverdueAchievement{}
+	for i := range refs {
+		ref := &refs[i]
+		achievement, err := s.repo.GetAchievementMongo(ctx, ref.MongoAchievementID)
+		if err != nil {
+			continue
+		}
+
+		dueAt := ref.SubmittedAt.Add(sla)
+		results = append(results, model.OverdueAchievement{
+			AchievementResponse: *s.combineAchievementResponse(achievement, ref),
+			DueAt:               dueAt,
+			DaysOverdue:         int(now.Sub(dueAt).Hours() / 24),
+		})
+	}
+
+	return results, nil
+}
+
+// slaCutoff is the latest submission time that is already past the SLA at now
+func (s *AchievementService) slaCutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -s.slaDays)
+}
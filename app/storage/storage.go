@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrObjectNotFound is returned when a key has no stored object
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage keeps attachment blobs under slash-separated keys. The local filesystem
+// implementation is the default; an S3-compatible backend only has to satisfy this interface.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+const defaultLocalDir = "./uploads"
+
+// DriverLocal keeps attachments on the local filesystem; it is the only STORAGE_DRIVER so far
+const DriverLocal = "local"
+
+// CheckEnv rejects an unsupported STORAGE_DRIVER so the server does not start with it
+func CheckEnv() error {
+	if driver := os.Getenv("STORAGE_DRIVER"); driver != "" && driver != DriverLocal {
+		return fmt.Errorf("unsupported STORAGE_DRIVER %q (supported: %s)", driver, DriverLocal)
+	}
+	return nil
+}
+
+// FromEnv builds the storage configured by STORAGE_DRIVER (default "local") rooted at
+// STORAGE_LOCAL_DIR. An unsupported driver yields a storage that fails every call instead
+// of silently writing to local disk.
+func FromEnv() Storage {
+	if err := CheckEnv(); err != nil {
+		return unavailableStorage{err: err}
+	}
+
+	dir := os.Getenv("STORAGE_LOCAL_DIR")
+	if dir == "" {
+		dir = defaultLocalDir
+	}
+	return NewLocalStorage(dir)
+}
+
+// unavailableStorage reports a configuration error on every call
+type unavailableStorage struct {
+	err error
+}
+
+func (s unavailableStorage) Put(ctx context.Context, key string, content io.Reader) error {
+	return s.err
+}
+
+func (s unavailableStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return nil, s.err
+}
+
+func (s unavailableStorage) Delete(ctx context.Context, key string) error {
+	return s.err
+}
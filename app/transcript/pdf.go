@@ -0,0 +1,206 @@
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// A4 portrait, in PDF points
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+type font int
+
+const (
+	regular font = iota
+	bold
+)
+
+// pdfDocument builds a PDF 1.4 file using the standard Helvetica fonts every viewer ships,
+// so nothing has to be embedded. Text is encoded as WinAnsi (Latin-1 plus a few typographic
+// characters); anything else is printed as '?'.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+}
+
+func (d *pdfDocument) addPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+}
+
+// text draws s with its baseline starting at x, y
+func (d *pdfDocument) text(x, y float64, f font, size float64, s string) {
+	fmt.Fprintf(d.page, "BT /F%d %.1f Tf 1 0 0 1 %.2f %.2f Tm (%s) Tj ET\n", f+1, size, x, y, pdfString(s))
+}
+
+// textRight draws s so that it ends at x
+func (d *pdfDocument) textRight(x, y float64, f font, size float64, s string) {
+	d.text(x-textWidth(s, f, size), y, f, size, s)
+}
+
+// textCenter draws s centred on the page
+func (d *pdfDocument) textCenter(y float64, f font, size float64, s string) {
+	d.text((pageWidth-textWidth(s, f, size))/2, y, f, size, s)
+}
+
+func (d *pdfDocument) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.page, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// fillRect paints a rectangle in a shade of grey (0 black, 1 white) with its lower left corner at x, y
+func (d *pdfDocument) fillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(d.page, "%.2f g %.2f %.2f %.2f %.2f re f 0 g\n", gray, x, y, w, h)
+}
+
+// writeTo writes the complete file: catalog, page tree, fonts, info, then a page and a content
+// stream per page, followed by the cross-reference table
+func (d *pdfDocument) writeTo(w io.Writer, title string, created time.Time) error {
+	out := bufio.NewWriter(w)
+	offset := 0
+	var offsets []int
+	write := func(s string) {
+		n, _ := out.WriteString(s)
+		offset += n
+	}
+	object := func(body string) {
+		offsets = append(offsets, offset)
+		write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", len(offsets), body))
+	}
+
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (student-report) /CreationDate (D:%s) >>",
+		pdfString(title), created.UTC().Format("20060102150405Z")))
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := offset
+	write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1))
+	for _, o := range offsets {
+		write(fmt.Sprintf("%010d 00000 n \n", o))
+	}
+	write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref))
+
+	return out.Flush()
+}
+
+// WinAnsi codes of the characters outside Latin-1 it can print
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// winAnsi encodes s for the standard fonts; control characters become spaces
+func winAnsi(s string) []byte {
+	encoded := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			encoded = append(encoded, ' ')
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			encoded = append(encoded, byte(r))
+		case winAnsiExtra[r] != 0:
+			encoded = append(encoded, winAnsiExtra[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// pdfString escapes s for a literal string operand
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, c := range winAnsi(s) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// Glyph widths (per 1000 units of font size) of the printable ASCII characters, from the
+// Helvetica and Helvetica-Bold font metrics
+var glyphWidths = [2][95]int{
+	{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// textWidth measures s in points; characters beyond ASCII are taken as wide as a digit
+func textWidth(s string, f font, size float64) float64 {
+	units := 0
+	for _, c := range winAnsi(s) {
+		if c >= 0x20 && c < 0x7f {
+			units += glyphWidths[f][c-0x20]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// wrap breaks s into lines no wider than width, splitting words that do not fit on a line
+func wrap(s string, f font, size, width float64) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if textWidth(candidate, f, size) <= width {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = word
+		for textWidth(current, f, size) > width {
+			runes := []rune(current)
+			n := len(runes) - 1
+			for n > 1 && textWidth(string(runes[:n]), f, size) > width {
+				n--
+			}
+			lines = append(lines, string(runes[:n]))
+			current = string(runes[n:])
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
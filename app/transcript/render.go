@@ -0,0 +1,428 @@
+package transcript
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report/app/model"
+)
+
+// Layout, in points
+const (
+	margin       = 50.0
+	contentWidth = pageWidth - 2*margin
+	bottomLimit  = 70.0 // rows never run into the footer
+	cellPadding  = 4.0
+)
+
+// Table columns: number, achievement, date, verifier, points
+var columnWidths = []float64{24, 221, 70, 130, 50}
+
+var columnTitles = []string{"No", "Prestasi", "Tanggal", "Diverifikasi oleh", "Poin"}
+
+// Sections are printed in this order, other types after them alphabetically
+var typeOrder = []string{"competition", "publication", "organization", "certification", "academic", "other"}
+
+var typeLabels = map[string]string{
+	"competition":   "Kompetisi",
+	"publication":   "Publikasi",
+	"organization":  "Organisasi",
+	"certification": "Sertifikasi",
+	"academic":      "Akademik",
+	"other":         "Lainnya",
+}
+
+var levelLabels = map[string]string{
+	"international": "Internasional",
+	"national":      "Nasional",
+	"regional":      "Regional",
+	"local":         "Lokal",
+}
+
+var monthNames = []string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// line is one line of text in a table cell
+type line struct {
+	text string
+	font font
+	size float64
+}
+
+// section is the verified achievements of one type
+type section struct {
+	achievementType string
+	rows            [][][]line // row, column, lines
+	points          int
+}
+
+// RenderPDF writes the transcript of the student's verified achievements grouped by type, with
+// per-type and overall totals, the verifier of each achievement, the issue date and the
+// document number on every page
+func RenderPDF(w io.Writer, t *model.Transcript) error {
+	r := &renderer{transcript: t, doc: &pdfDocument{}}
+	r.render()
+	return r.doc.writeTo(w, "Transkrip Prestasi "+t.Report.StudentName+" "+t.Document.Number, t.Document.IssuedAt)
+}
+
+type renderer struct {
+	transcript *model.Transcript
+	doc        *pdfDocument
+	y          float64 // baseline of the next line
+}
+
+func (r *renderer) render() {
+	report, document := r.transcript.Report, r.transcript.Document
+
+	r.doc.addPage()
+	r.y = pageHeight - margin - 14
+	r.doc.textCenter(r.y, bold, 14, "SURAT KETERANGAN PENDAMPING IJAZAH")
+	r.y -= 18
+	r.doc.textCenter(r.y, regular, 11, "Transkrip Prestasi Mahasiswa")
+	r.y -= 16
+	r.doc.textCenter(r.y, regular, 10, "Nomor: "+document.Number)
+	r.y -= 12
+	r.doc.line(margin, r.y, pageWidth-margin, r.y, 1)
+	r.y -= 20
+
+	period := "Seluruh periode"
+	if report.Period != "" {
+		period = report.Period
+	}
+	identity := [][2]string{
+		{"Nama", report.StudentName},
+		{"NIM", report.NIM},
+		{"Program Studi", report.ProgramStudy},
+		{"Periode", period},
+		{"Tanggal terbit", formatDate(document.IssuedAt)},
+	}
+	for _, field := range identity {
+		if field[1] == "" {
+			continue
+		}
+		r.doc.text(margin, r.y, bold, 10, field[0])
+		r.doc.text(margin+100, r.y, regular, 10, ": "+field[1])
+		r.y -= 15
+	}
+	r.y -= 10
+
+	sections, verified, clipped := r.sections()
+	if len(sections) == 0 {
+		r.doc.text(margin, r.y, regular, 10, "Belum ada prestasi terverifikasi.")
+		r.y -= 20
+	}
+	for _, s := range sections {
+		r.section(s)
+	}
+
+	r.summary(verified, clipped)
+	r.footers()
+}
+
+// sections groups the verified achievements by type; it also returns how many there are and
+// whether a period cap clipped any of them
+func (r *renderer) sections() ([]section, int, bool) {
+	report := r.transcript.Report
+
+	counted := make(map[string]int)
+	for _, c := range report.Clipped {
+		counted[c.AchievementID] = c.CountedPoints
+	}
+
+	byType := make(map[string]*section)
+	verified, anyClipped := 0, false
+	for i := range report.Achievements {
+		achievement := &report.Achievements[i]
+		if achievement.Status != "verified" {
+			continue
+		}
+		verified++
+
+		s, exists := byType[achievement.AchievementType]
+		if !exists {
+			s = &section{achievementType: achievement.AchievementType}
+			byType[achievement.AchievementType] = s
+		}
+
+		// The points that count: expired certifications at the expiry weight, then period caps
+		points := achievement.Points
+		if achievement.ExpiredAt != nil {
+			points = points * report.ExpiredWeight / 100
+		}
+		pointsText := strconv.Itoa(points)
+		if capped, isClipped := counted[achievement.ID.Hex()]; isClipped {
+			points = capped
+			pointsText = strconv.Itoa(points) + "*"
+			anyClipped = true
+		}
+		s.points += points
+
+		s.rows = append(s.rows, [][]line{
+			{{text: strconv.Itoa(len(s.rows) + 1), font: regular, size: 9}},
+			r.achievementLines(achievement),
+			{{text: formatShortDate(achievement.ReportDate()), font: regular, size: 9}},
+			r.verifierLines(achievement),
+			{{text: pointsText, font: regular, size: 9}},
+		})
+	}
+
+	sections := make([]section, 0, len(byType))
+	for _, name := range typeOrder {
+		if s, exists := byType[name]; exists {
+			sections = append(sections, *s)
+			delete(byType, name)
+		}
+	}
+	var others []string
+	for name := range byType {
+		others = append(others, name)
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		sections = append(sections, *byType[name])
+	}
+
+	return sections, verified, anyClipped
+}
+
+// achievementLines is the title followed by a summary of the type-specific details
+func (r *renderer) achievementLines(achievement *model.AchievementResponse) []line {
+	width := columnWidths[1] - 2*cellPadding
+	var lines []line
+	for _, text := range wrap(achievement.Title, bold, 9, width) {
+		lines = append(lines, line{text: text, font: bold, size: 9})
+	}
+
+	d := achievement.Details
+	var parts []string
+	add := func(values ...string) {
+		for _, v := range values {
+			if v != "" {
+				parts = append(parts, v)
+			}
+		}
+	}
+	switch achievement.AchievementType {
+	case "competition":
+		add(d.CompetitionName, levelLabels[d.CompetitionLevel])
+		if d.Rank > 0 {
+			add("Peringkat " + strconv.Itoa(d.Rank))
+		}
+		add(d.MedalType)
+	case "publication":
+		add(d.PublicationTitle, d.PublicationType, d.Publisher, d.ISSN, d.DOI)
+	case "organization":
+		add(d.OrganizationName, d.Position)
+		if d.Period != nil && !d.Period.Start.IsZero() {
+			add(formatShortDate(d.Period.Start) + " - " + formatShortDate(d.Period.End))
+		}
+	case "certification":
+		add(d.CertificationName, d.IssuedBy, d.CertificationNumber)
+		if d.ValidUntil != nil {
+			add("berlaku s.d. " + formatShortDate(*d.ValidUntil))
+		}
+	}
+	add(d.Organizer, d.Location)
+	if achievement.IsTeam() {
+		for _, member := range achievement.TeamMembers {
+			if member.StudentID == r.transcript.Report.StudentID {
+				add(fmt.Sprintf("Tim %d orang, peran: %s", len(achievement.TeamMembers), member.Role))
+			}
+		}
+	}
+	if achievement.ExpiredAt != nil {
+		add("kedaluwarsa")
+	}
+
+	if len(parts) > 0 {
+		for _, text := range wrap(strings.Join(parts, " · "), regular, 8, width) {
+			lines = append(lines, line{text: text, font: regular, size: 8})
+		}
+	}
+	return lines
+}
+
+// verifierLines is the verifier's name and the verification date
+func (r *renderer) verifierLines(achievement *model.AchievementResponse) []line {
+	name := "-"
+	if achievement.VerifiedBy != nil {
+		if name = r.transcript.VerifierNames[*achievement.VerifiedBy]; name == "" {
+			name = *achievement.VerifiedBy
+		}
+	}
+	var lines []line
+	for _, text := range wrap(name, regular, 9, columnWidths[3]-2*cellPadding) {
+		lines = append(lines, line{text: text, font: regular, size: 9})
+	}
+	if achievement.VerifiedAt != nil {
+		lines = append(lines, line{text: formatShortDate(*achievement.VerifiedAt), font: regular, size: 8})
+	}
+	return lines
+}
+
+// section draws the type heading, the table and the subtotal, carrying over to new pages
+func (r *renderer) section(s section) {
+	label := typeLabel(s.achievementType)
+	heading := func(suffix string) {
+		r.doc.text(margin, r.y, bold, 11, fmt.Sprintf("%s (%d prestasi)%s", label, len(s.rows), suffix))
+		r.y -= 8
+		r.tableHeader()
+	}
+
+	// Keep the heading with at least the first row
+	if r.y-26-rowHeight(s.rows[0]) < bottomLimit {
+		r.newPage()
+	}
+	heading("")
+	for _, row := range s.rows {
+		if r.y-rowHeight(row) < bottomLimit {
+			r.newPage()
+			heading(" - lanjutan")
+		}
+		r.row(row, false)
+	}
+
+	subtotal := [][]line{{}, {{text: "Subtotal " + label, font: bold, size: 9}}, {}, {}, {{text: strconv.Itoa(s.points), font: bold, size: 9}}}
+	if r.y-rowHeight(subtotal) < bottomLimit {
+		r.newPage()
+	}
+	r.row(subtotal, true)
+	r.y -= 18
+}
+
+func (r *renderer) tableHeader() {
+	cells := make([][]line, len(columnTitles))
+	for i, title := range columnTitles {
+		cells[i] = []line{{text: title, font: bold, size: 9}}
+	}
+	r.row(cells, true)
+}
+
+// row draws one table row below r.y and moves r.y under it
+func (r *renderer) row(cells [][]line, shaded bool) {
+	height := rowHeight(cells)
+	top := r.y
+	if shaded {
+		r.doc.fillRect(margin, top-height, contentWidth, height, 0.92)
+	}
+
+	x := margin
+	for i, lines := range cells {
+		y := top - cellPadding
+		for _, l := range lines {
+			y -= l.size
+			if i == len(cells)-1 {
+				r.doc.textRight(x+columnWidths[i]-cellPadding, y, l.font, l.size, l.text)
+			} else {
+				r.doc.text(x+cellPadding, y, l.font, l.size, l.text)
+			}
+			y -= l.size * 0.3
+		}
+		x += columnWidths[i]
+	}
+	r.doc.line(margin, top-height, pageWidth-margin, top-height, 0.5)
+	r.y = top - height
+}
+
+func rowHeight(cells [][]line) float64 {
+	height := 0.0
+	for _, lines := range cells {
+		h := 0.0
+		for _, l := range lines {
+			h += l.size * 1.3
+		}
+		if h > height {
+			height = h
+		}
+	}
+	return height + 2*cellPadding
+}
+
+// summary prints the totals and the issuance statement
+func (r *renderer) summary(verified int, clipped bool) {
+	report, document := r.transcript.Report, r.transcript.Document
+
+	lines := [][2]string{
+		{"Jumlah prestasi terverifikasi", strconv.Itoa(verified)},
+		{"Total poin", strconv.Itoa(report.TotalPoints)},
+	}
+	if report.RawTotalPoints != report.TotalPoints {
+		lines = append(lines, [2]string{"Total poin sebelum batas periode", strconv.Itoa(report.RawTotalPoints)})
+	}
+	var notes []string
+	if clipped {
+		notes = append(notes, "* Poin dibatasi oleh batas poin per periode.")
+	}
+	if report.ExpiredPoints > 0 {
+		notes = append(notes, fmt.Sprintf("Sertifikasi kedaluwarsa dihitung %d%% dari poinnya.", report.ExpiredWeight))
+	}
+	statement := wrap(fmt.Sprintf("Dokumen ini diterbitkan secara elektronik pada %s dengan nomor %s dan hanya memuat prestasi yang telah diverifikasi.",
+		formatDate(document.IssuedAt), document.Number), regular, 9, contentWidth)
+
+	if r.y-float64(len(lines))*15-float64(len(notes)+len(statement))*12-20 < bottomLimit {
+		r.newPage()
+	}
+	for _, l := range lines {
+		r.doc.text(margin, r.y, bold, 10, l[0])
+		r.doc.textRight(pageWidth-margin, r.y, bold, 10, l[1])
+		r.y -= 15
+	}
+	for _, note := range notes {
+		r.doc.text(margin, r.y, regular, 8, note)
+		r.y -= 12
+	}
+	r.y -= 8
+	for _, text := range statement {
+		r.doc.text(margin, r.y, regular, 9, text)
+		r.y -= 12
+	}
+}
+
+// newPage starts a continuation page headed by the student and document number
+func (r *renderer) newPage() {
+	report, document := r.transcript.Report, r.transcript.Document
+	r.doc.addPage()
+	r.y = pageHeight - margin
+	r.doc.text(margin, r.y, regular, 8, fmt.Sprintf("Transkrip Prestasi - %s (%s)", report.StudentName, report.NIM))
+	r.doc.textRight(pageWidth-margin, r.y, regular, 8, "Nomor: "+document.Number)
+	r.y -= 6
+	r.doc.line(margin, r.y, pageWidth-margin, r.y, 0.5)
+	r.y -= 20
+}
+
+// footers number the pages once their count is known
+func (r *renderer) footers() {
+	number := r.transcript.Document.Number
+	for i, page := range r.doc.pages {
+		r.doc.page = page
+		r.doc.line(margin, 50, pageWidth-margin, 50, 0.5)
+		r.doc.text(margin, 38, regular, 8, number)
+		r.doc.textRight(pageWidth-margin, 38, regular, 8, fmt.Sprintf("Halaman %d dari %d", i+1, len(r.doc.pages)))
+	}
+}
+
+func typeLabel(achievementType string) string {
+	if label, exists := typeLabels[achievementType]; exists {
+		return label
+	}
+	if achievementType == "" {
+		return "Lainnya"
+	}
+	return strings.ToUpper(achievementType[:1]) + achievementType[1:]
+}
+
+// formatDate writes a date the Indonesian way, e.g. 17 Oktober 2026
+func formatDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[t.Month()-1], t.Year())
+}
+
+// formatShortDate abbreviates the month, e.g. 17 Okt 2026
+func formatShortDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%02d %s %d", t.Day(), monthNames[t.Month()-1][:3], t.Year())
+}
@@ -0,0 +1,14 @@
+-- Audit trail for every achievement status transition
+CREATE TABLE IF NOT EXISTS achievement_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(64) NOT NULL,
+    actor_role VARCHAR(20) NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_status_history_ref
+    ON achievement_status_history (achievement_ref_id, created_at);
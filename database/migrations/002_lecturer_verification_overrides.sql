@@ -0,0 +1,7 @@
+-- Lecturers (e.g. department heads) allowed to verify achievements of every
+-- student advised within their own department, not only their advisees
+CREATE TABLE IF NOT EXISTS lecturer_verification_overrides (
+    lecturer_id UUID PRIMARY KEY REFERENCES lecturers(id) ON DELETE CASCADE,
+    granted_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,30 @@
+-- Configurable approval chains keyed by achievement type and competition level.
+-- An empty competition_level applies to every level of that type. Types without
+-- any rows use the single advisor stage.
+CREATE TABLE IF NOT EXISTS approval_chain_stages (
+    id SERIAL PRIMARY KEY,
+    achievement_type VARCHAR(50) NOT NULL,
+    competition_level VARCHAR(50) NOT NULL DEFAULT '',
+    stage_order INT NOT NULL,
+    stage_name VARCHAR(50) NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    advisor_only BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (achievement_type, competition_level, stage_order)
+);
+
+INSERT INTO approval_chain_stages (achievement_type, competition_level, stage_order, stage_name, permission, advisor_only)
+VALUES
+    ('competition', 'national', 1, 'advisor', 'achievement:verify', TRUE),
+    ('competition', 'national', 2, 'department', 'achievement:approve_department', FALSE),
+    ('competition', 'international', 1, 'advisor', 'achievement:verify', TRUE),
+    ('competition', 'international', 2, 'department', 'achievement:approve_department', FALSE),
+    ('competition', 'international', 3, 'faculty', 'achievement:approve_faculty', FALSE)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, resource, action, description)
+SELECT 'achievement:approve_department', 'achievement', 'approve_department', 'Approve achievements at department level'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:approve_department');
+
+INSERT INTO permissions (name, resource, action, description)
+SELECT 'achievement:approve_faculty', 'achievement', 'approve_faculty', 'Approve achievements at faculty level'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:approve_faculty');
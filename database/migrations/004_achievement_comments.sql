@@ -0,0 +1,15 @@
+-- Review discussion threads on an achievement (student, advisor, admin)
+CREATE TABLE IF NOT EXISTS achievement_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES achievement_comments(id) ON DELETE CASCADE,
+    author_id VARCHAR(64) NOT NULL,
+    author_role VARCHAR(20) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'comment', -- comment, change_request
+    body TEXT NOT NULL,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_ref
+    ON achievement_comments (achievement_ref_id, created_at);
@@ -0,0 +1,15 @@
+-- Temporary hand-over of an advisor's verification duties (e.g. sabbatical leave)
+CREATE TABLE IF NOT EXISTS lecturer_delegations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delegator_id UUID NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    delegate_id UUID NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (delegator_id <> delegate_id),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lecturer_delegations_delegate
+    ON lecturer_delegations (delegate_id, start_date, end_date);
@@ -0,0 +1,22 @@
+-- Admin-editable points rule table; every save creates a new version so each
+-- achievement can record which version scored it
+CREATE TABLE IF NOT EXISTS points_rule_versions (
+    version SERIAL PRIMARY KEY,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS points_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    version INT NOT NULL REFERENCES points_rule_versions(version) ON DELETE CASCADE,
+    position INT NOT NULL,
+    achievement_type VARCHAR(50) NOT NULL,
+    competition_level VARCHAR(50) NOT NULL DEFAULT '',
+    medal_type VARCHAR(50) NOT NULL DEFAULT '',
+    publication_type VARCHAR(50) NOT NULL DEFAULT '',
+    min_rank INT NOT NULL DEFAULT 0,
+    max_rank INT NOT NULL DEFAULT 0,
+    points INT NOT NULL CHECK (points >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_rules_version ON points_rules (version, position);
@@ -0,0 +1,8 @@
+-- Per-type point caps per academic period, saved with each points rule version
+CREATE TABLE IF NOT EXISTS points_caps (
+    version INT NOT NULL REFERENCES points_rule_versions(version) ON DELETE CASCADE,
+    achievement_type VARCHAR(50) NOT NULL,
+    period VARCHAR(20) NOT NULL CHECK (period IN ('semester', 'academic_year')),
+    max_points INT NOT NULL CHECK (max_points >= 0),
+    PRIMARY KEY (version, achievement_type)
+);
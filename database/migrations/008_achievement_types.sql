@@ -0,0 +1,21 @@
+-- Admin-managed achievement types; existing achievements keep referencing the name,
+-- so types are deactivated rather than deleted
+CREATE TABLE IF NOT EXISTS achievement_types (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    required_fields TEXT[] NOT NULL DEFAULT '{}',
+    custom_fields_schema JSONB,
+    default_points INT NOT NULL DEFAULT 0 CHECK (default_points >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO achievement_types (name, description, required_fields, default_points) VALUES
+    ('academic', 'Academic honours such as dean''s list or scholarships', '{}', 30),
+    ('competition', 'Competitions and contests', '{competitionName,competitionLevel}', 5),
+    ('organization', 'Organization and committee roles', '{organizationName,position}', 20),
+    ('publication', 'Journal, conference and book publications', '{publicationType,publicationTitle}', 20),
+    ('certification', 'Professional certifications', '{certificationName,issuedBy}', 25),
+    ('other', 'Anything that does not fit another type', '{}', 10)
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,15 @@
+-- Evidence a student must attach (or reference) before submitting an achievement of a type
+ALTER TABLE achievement_types
+    ADD COLUMN IF NOT EXISTS evidence_requirements JSONB NOT NULL DEFAULT '[]';
+
+UPDATE achievement_types SET evidence_requirements =
+    '[{"name": "certificate", "description": "Certificate or official result letter", "fileTypes": ["application/pdf", "image/jpeg", "image/png", "image/webp"]}]'
+WHERE name = 'competition';
+
+UPDATE achievement_types SET evidence_requirements =
+    '[{"name": "publication", "description": "DOI or the published PDF", "fileTypes": ["application/pdf"], "detailFields": ["doi"]}]'
+WHERE name = 'publication';
+
+UPDATE achievement_types SET evidence_requirements =
+    '[{"name": "certificate", "description": "Scan of the certificate", "fileTypes": ["application/pdf", "image/jpeg", "image/png", "image/webp"]}]'
+WHERE name = 'certification';
@@ -0,0 +1,9 @@
+-- How team achievement points are shared: every member gets the full points
+-- (duplicate) or the points are divided among the members (split)
+ALTER TABLE points_rule_versions
+    ADD COLUMN IF NOT EXISTS team_policy VARCHAR(20) NOT NULL DEFAULT 'duplicate'
+    CHECK (team_policy IN ('duplicate', 'split'));
+
+-- A team achievement has one reference per member pointing at the same document
+CREATE INDEX IF NOT EXISTS idx_achievement_references_mongo_id
+    ON achievement_references (mongo_achievement_id, created_at);
@@ -0,0 +1,24 @@
+-- Admin-managed academic periods; reports bucket an achievement into the period its
+-- event date (or creation date when it has none) falls in. end_date is inclusive.
+CREATE TABLE IF NOT EXISTS academic_periods (
+    code VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    academic_year VARCHAR(9) NOT NULL,
+    semester VARCHAR(10) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (start_date <= end_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_academic_periods_dates ON academic_periods (start_date, end_date);
+
+INSERT INTO academic_periods (code, name, academic_year, semester, start_date, end_date) VALUES
+    ('2023-GANJIL', 'Semester Ganjil 2023/2024', '2023/2024', 'GANJIL', '2023-08-01', '2024-01-31'),
+    ('2023-GENAP', 'Semester Genap 2023/2024', '2023/2024', 'GENAP', '2024-02-01', '2024-07-31'),
+    ('2024-GANJIL', 'Semester Ganjil 2024/2025', '2024/2025', 'GANJIL', '2024-08-01', '2025-01-31'),
+    ('2024-GENAP', 'Semester Genap 2024/2025', '2024/2025', 'GENAP', '2025-02-01', '2025-07-31'),
+    ('2025-GANJIL', 'Semester Ganjil 2025/2026', '2025/2026', 'GANJIL', '2025-08-01', '2026-01-31'),
+    ('2025-GENAP', 'Semester Genap 2025/2026', '2025/2026', 'GENAP', '2026-02-01', '2026-07-31')
+ON CONFLICT (code) DO NOTHING;
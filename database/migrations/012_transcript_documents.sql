@@ -0,0 +1,16 @@
+-- Issued achievement transcripts (SKPI attachment); every PDF gets a new, unique
+-- document number such as SKPI/2026/000042 so a printed copy can be traced back
+CREATE SEQUENCE IF NOT EXISTS transcript_document_seq;
+
+CREATE TABLE IF NOT EXISTS transcript_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    document_number VARCHAR(32) NOT NULL UNIQUE,
+    student_id UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    period VARCHAR(20) NOT NULL DEFAULT '', -- academic period code, empty for all time
+    verified_achievements INT NOT NULL,
+    total_points INT NOT NULL,
+    issued_by UUID NOT NULL REFERENCES users(id),
+    issued_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcript_documents_student ON transcript_documents (student_id, issued_at);
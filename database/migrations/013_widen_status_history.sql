@@ -0,0 +1,5 @@
+-- Approval statuses are <stage_name>_approved and stage_name allows 50 characters,
+-- which no longer fits the 20 characters the history columns were created with
+ALTER TABLE achievement_status_history
+    ALTER COLUMN from_status TYPE VARCHAR(64),
+    ALTER COLUMN to_status TYPE VARCHAR(64);
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"student-report/config"
 	"student-report/database"
 	_ "student-report/docs"
 	"student-report/middleware"
+	"student-report/app/repository"
+	"student-report/app/service"
+	"student-report/app/storage"
 	"student-report/route"
 
 	"github.com/gofiber/fiber/v2"
@@ -29,12 +33,30 @@ import (
 // @schemes http
 func main() {
 	config.LoadEnv()
+	if err := storage.CheckEnv(); err != nil {
+		log.Fatal(err)
+	}
 	postgres := database.PostgreConn()
 	mongoDB := database.MongoConn()
 
 	services := config.InitializeServices(postgres, mongoDB)
 
-	app := fiber.New()
+	achievementRepo := repository.NewAchievementRepository(mongoDB, postgres)
+	if err := achievementRepo.EnsureRevisionIndex(context.Background()); err != nil {
+		log.Printf("Peringatan: gagal membuat index achievement_revisions: %v", err)
+	}
+	if err := achievementRepo.BackfillEventDates(context.Background()); err != nil {
+		log.Printf("Peringatan: gagal mengisi eventAt achievement lama: %v", err)
+	}
+
+	// Background job flagging certifications whose validity has ended
+	expiryService := service.NewAchievementService(achievementRepo)
+	go expiryService.RunCertificationExpiryJob(context.Background())
+
+	// Leave room for multipart overhead on top of the attachment size limit
+	app := fiber.New(fiber.Config{
+		BodyLimit: int(service.AttachmentMaxBytes()) + 1<<20,
+	})
 	app.Use(middleware.LoggerMiddleware)
 
 	route.RegisterRoutes(app, postgres, mongoDB, services)
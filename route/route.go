@@ -11,6 +11,9 @@ import (
 	"github.com/gofiber/swagger"
 )
 
+// Any approval stage permission is enough to reach the verify endpoints; the stage itself is checked in the service
+var verifyPermissions = []string{"achievement:verify", "achievement:approve_department", "achievement:approve_faculty"}
+
 func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, services *config.ServiceContainer) {
 	// Homepage (GET 127.0.0.1:3000)
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -89,6 +92,27 @@ func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, service
 		return services.LecturerService.GetLecturersService(c)
 	})
 
+	lecturers.Post("/:id/verification-override", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return services.LecturerService.GrantVerificationOverrideService(c)
+	})
+
+	lecturers.Delete("/:id/verification-override", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return services.LecturerService.RevokeVerificationOverrideService(c)
+	})
+
+	// Verification delegation while an advisor is on leave (Admin or the lecturer)
+	lecturers.Get("/:id/delegations", func(c *fiber.Ctx) error {
+		return services.LecturerService.GetDelegationsService(c)
+	})
+
+	lecturers.Post("/:id/delegations", func(c *fiber.Ctx) error {
+		return services.LecturerService.CreateDelegationService(c)
+	})
+
+	lecturers.Delete("/:id/delegations/:delegationId", func(c *fiber.Ctx) error {
+		return services.LecturerService.DeleteDelegationService(c)
+	})
+
 	achievements := protected.Group("/achievements")
 
 	// FR-003: Create Achievement (Mahasiswa)
@@ -106,6 +130,26 @@ func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, service
 		return service.GetAdviseesAchievementsService(c, db, mongoDB)
 	})
 
+	// Approval queue per stage (?stage=advisor|department|faculty)
+	achievements.Get("/approval-queue", middleware.RequireAnyPermission(verifyPermissions...), func(c *fiber.Ctx) error {
+		return service.GetApprovalQueueService(c, db, mongoDB)
+	})
+
+	// Submissions waiting longer than the verification SLA
+	achievements.Get("/overdue", middleware.RequireAnyPermission(verifyPermissions...), func(c *fiber.Ctx) error {
+		return service.GetOverdueAchievementsService(c, db, mongoDB)
+	})
+
+	// Certifications expiring soon (?within=30d) so they can be renewed
+	achievements.Get("/expiring", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.GetExpiringAchievementsService(c, db, mongoDB)
+	})
+
+	// Batch Verify/Reject Achievements (Dosen Wali)
+	achievements.Post("/verify-batch", middleware.RequireAnyPermission(verifyPermissions...), func(c *fiber.Ctx) error {
+		return service.VerifyAchievementsBatchService(c, db, mongoDB)
+	})
+
 	// FR-010: Get All Achievements (Admin)
 	achievements.Get("/", middleware.RequirePermission("report:view"), func(c *fiber.Ctx) error {
 		return service.GetAllAchievementsWithFilterService(c, db, mongoDB)
@@ -116,7 +160,7 @@ func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, service
 		return service.GetAchievementByIDService(c, db, mongoDB)
 	})
 
-	// Update Achievement (Mahasiswa - draft only)
+	// Update Achievement (Mahasiswa - draft or rejected)
 	achievements.Put("/:id", middleware.RequirePermission("achievement:update"), func(c *fiber.Ctx) error {
 		return service.UpdateAchievementService(c, db, mongoDB)
 	})
@@ -126,8 +170,18 @@ func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, service
 		return service.SubmitAchievementService(c, db, mongoDB)
 	})
 
+	// Resubmit Rejected Achievement (Mahasiswa)
+	achievements.Post("/:id/resubmit", middleware.RequirePermission("achievement:submit"), func(c *fiber.Ctx) error {
+		return service.ResubmitAchievementService(c, db, mongoDB)
+	})
+
+	// Withdraw Submitted Achievement back to draft (Mahasiswa)
+	achievements.Post("/:id/withdraw", middleware.RequirePermission("achievement:submit"), func(c *fiber.Ctx) error {
+		return service.WithdrawAchievementService(c, db, mongoDB)
+	})
+
 	// FR-007, FR-008: Verify/Reject Achievement (Dosen Wali)
-	achievements.Post("/:id/verify", middleware.RequirePermission("achievement:verify"), func(c *fiber.Ctx) error {
+	achievements.Post("/:id/verify", middleware.RequireAnyPermission(verifyPermissions...), func(c *fiber.Ctx) error {
 		return service.VerifyAchievementService(c, db, mongoDB)
 	})
 
@@ -136,11 +190,116 @@ func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, service
 		return service.DeleteAchievementService(c, db, mongoDB)
 	})
 
+	// Status transition history
+	achievements.Get("/:id/history", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.GetAchievementHistoryService(c, db, mongoDB)
+	})
+
+	// Content revisions and diff between revisions
+	achievements.Get("/:id/revisions", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.GetAchievementRevisionsService(c, db, mongoDB)
+	})
+
+	achievements.Get("/:id/revisions/:a/diff/:b", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.DiffAchievementRevisionsService(c, db, mongoDB)
+	})
+
+	// Review discussion threads
+	achievements.Get("/:id/comments", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.GetAchievementCommentsService(c, db, mongoDB)
+	})
+
+	achievements.Post("/:id/comments", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.AddAchievementCommentService(c, db, mongoDB)
+	})
+
+	achievements.Post("/:id/comments/:commentId/resolve", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.ResolveAchievementCommentService(c, db, mongoDB)
+	})
+
 	// Upload Attachment
 	achievements.Post("/:id/attachments", middleware.RequirePermission("achievement:create"), func(c *fiber.Ctx) error {
 		return service.UploadAttachmentService(c, db, mongoDB)
 	})
 
+	// Download Attachment
+	achievements.Get("/:id/attachments/:attachmentId", middleware.RequirePermission("achievement:read"), func(c *fiber.Ctx) error {
+		return service.DownloadAttachmentService(c, db, mongoDB)
+	})
+
+	// Replace or Delete Attachment (Mahasiswa - draft or rejected)
+	achievements.Put("/:id/attachments/:attachmentId", middleware.RequirePermission("achievement:update"), func(c *fiber.Ctx) error {
+		return service.ReplaceAttachmentService(c, db, mongoDB)
+	})
+
+	achievements.Delete("/:id/attachments/:attachmentId", middleware.RequirePermission("achievement:update"), func(c *fiber.Ctx) error {
+		return service.DeleteAttachmentService(c, db, mongoDB)
+	})
+
+	// Approval chain configuration (Admin)
+	approvalChains := protected.Group("/approval-chains", middleware.AdminOnly())
+
+	approvalChains.Get("/", func(c *fiber.Ctx) error {
+		return service.GetApprovalChainsService(c, db, mongoDB)
+	})
+
+	approvalChains.Put("/", func(c *fiber.Ctx) error {
+		return service.SaveApprovalChainService(c, db, mongoDB)
+	})
+
+	// Achievement types (list for everyone, managed by Admin)
+	achievementTypes := protected.Group("/achievement-types")
+
+	achievementTypes.Get("/", func(c *fiber.Ctx) error {
+		return service.GetAchievementTypesService(c, db, mongoDB)
+	})
+
+	achievementTypes.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CreateAchievementTypeService(c, db, mongoDB)
+	})
+
+	achievementTypes.Put("/:name", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UpdateAchievementTypeService(c, db, mongoDB)
+	})
+
+	achievementTypes.Delete("/:name", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DeleteAchievementTypeService(c, db, mongoDB)
+	})
+
+	// Points rule table (Admin)
+	pointsRules := protected.Group("/points-rules", middleware.AdminOnly())
+
+	pointsRules.Get("/", func(c *fiber.Ctx) error {
+		return service.GetPointsRulesService(c, db, mongoDB)
+	})
+
+	pointsRules.Put("/", func(c *fiber.Ctx) error {
+		return service.SavePointsRulesService(c, db, mongoDB)
+	})
+
+	pointsRules.Post("/recompute", func(c *fiber.Ctx) error {
+		return service.RecomputePointsService(c, db, mongoDB)
+	})
+
+	// Academic periods (list for everyone, managed by Admin)
+	academicPeriods := protected.Group("/academic-periods")
+
+	academicPeriods.Get("/", func(c *fiber.Ctx) error {
+		return service.GetAcademicPeriodsService(c, db, mongoDB)
+	})
+
+	academicPeriods.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CreateAcademicPeriodService(c, db, mongoDB)
+	})
+
+	academicPeriods.Put("/:code", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UpdateAcademicPeriodService(c, db, mongoDB)
+	})
+
+	academicPeriods.Delete("/:code", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DeleteAcademicPeriodService(c, db, mongoDB)
+	})
+
 	reports := protected.Group("/reports")
 	
 	// FR-011: Get Statistics (role-based)
@@ -148,8 +307,18 @@ func RegisterRoutes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database, service
 		return service.GetStatisticsService(c, db, mongoDB)
 	})
 	
+	// Achievement trends for dashboard charts (role-based)
+	reports.Get("/trends", func(c *fiber.Ctx) error {
+		return service.GetTrendsService(c, db, mongoDB)
+	})
+	
 	// Get Student Report
 	reports.Get("/student/:id", middleware.RequirePermission("report:view"), func(c *fiber.Ctx) error {
 		return service.GetStudentReportService(c, db, mongoDB)
 	})
+	
+	// Student transcript PDF (SKPI attachment of verified achievements)
+	reports.Get("/student/:id/transcript", middleware.RequirePermission("report:view"), func(c *fiber.Ctx) error {
+		return service.GetStudentTranscriptService(c, db, mongoDB)
+	})
 }
\ No newline at end of file
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+	"time"
+)
+
+// Test Saving Academic Periods
+func TestAchievementService_SaveAcademicPeriod(t *testing.T) {
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	period, err := svc.SaveAcademicPeriod("2025-ganjil", model.SaveAcademicPeriodRequest{StartDate: "2025-08-01", EndDate: "2026-01-31"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if period.Code != "2025-GANJIL" || period.AcademicYear != "2025/2026" || period.Name != "Semester Ganjil 2025/2026" {
+		t.Errorf("unexpected period: %+v", period)
+	}
+
+	tests := []struct {
+		name string
+		code string
+		req  model.SaveAcademicPeriodRequest
+	}{
+		{"bad code", "2025-SUMMER", model.SaveAcademicPeriodRequest{StartDate: "2026-02-01", EndDate: "2026-07-31"}},
+		{"bad date", "2025-GENAP", model.SaveAcademicPeriodRequest{StartDate: "01-02-2026", EndDate: "2026-07-31"}},
+		{"end before start", "2025-GENAP", model.SaveAcademicPeriodRequest{StartDate: "2026-07-31", EndDate: "2026-02-01"}},
+		{"overlap", "2025-GENAP", model.SaveAcademicPeriodRequest{StartDate: "2026-01-15", EndDate: "2026-07-31"}},
+	}
+	for _, tt := range tests {
+		if _, err := svc.SaveAcademicPeriod(tt.code, tt.req); !errors.Is(err, service.ErrValidation) {
+			t.Errorf("%s: expected ErrValidation, got %v", tt.name, err)
+		}
+	}
+
+	// Updating a period may move its own dates
+	if _, err := svc.SaveAcademicPeriod("2025-GANJIL", model.SaveAcademicPeriodRequest{StartDate: "2025-09-01", EndDate: "2026-01-31"}); err != nil {
+		t.Errorf("expected the period to be updated, got %v", err)
+	}
+
+	if _, err := svc.ResolveAcademicPeriod("2030-GANJIL"); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected an unknown period to be rejected, got %v", err)
+	}
+	if resolved, err := svc.ResolveAcademicPeriod(""); err != nil || resolved != nil {
+		t.Errorf("expected no period for an empty code, got %+v %v", resolved, err)
+	}
+
+	if err := svc.DeleteAcademicPeriod("2025-GANJIL"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteAcademicPeriod("2025-GANJIL"); !errors.Is(err, service.ErrAchievementNotFound) {
+		t.Errorf("expected ErrAchievementNotFound, got %v", err)
+	}
+}
+
+// Test Reports Filtered by Academic Period
+func TestAchievementService_PeriodFilter(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	now := time.Now()
+	current, err := svc.SaveAcademicPeriod("2099-GANJIL", model.SaveAcademicPeriodRequest{
+		StartDate: now.AddDate(0, 0, -7).Format("2006-01-02"),
+		EndDate:   now.AddDate(0, 0, 7).Format("2006-01-02"),
+	})
+	if err != nil {
+		t.Fatalf("failed to save period: %v", err)
+	}
+	past, err := svc.SaveAcademicPeriod("2020-GANJIL", model.SaveAcademicPeriodRequest{StartDate: "2020-08-01", EndDate: "2021-01-31"})
+	if err != nil {
+		t.Fatalf("failed to save period: %v", err)
+	}
+
+	create := func(title, eventDate string) {
+		_, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "certification",
+			Title:           title,
+			Details:         model.AchievementDetails{CertificationName: title, IssuedBy: "Oracle", EventDate: eventDate},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+	}
+	create("Dated in 2020", "2020-10-10")
+	create("Undated, created now", "")
+
+	report, err := svc.GetStudentReportForPeriod(ctx, "student-1", past)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Period != "2020-GANJIL" || report.TotalAchievements != 1 || report.Achievements[0].Title != "Dated in 2020" {
+		t.Errorf("expected only the achievement dated in 2020, got %d in %q", report.TotalAchievements, report.Period)
+	}
+
+	// Without an event date the achievement falls in the period it was created in
+	report, err = svc.GetStudentReportForPeriod(ctx, "student-1", current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalAchievements != 1 || report.Achievements[0].Title != "Undated, created now" {
+		t.Errorf("expected only the undated achievement, got %d", report.TotalAchievements)
+	}
+
+	stats, err := svc.GetStatisticsForPeriod(ctx, nil, past)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Period != "2020-GANJIL" || stats.TotalAchievements != 1 || stats.ByStatus[service.StatusDraft] != 1 {
+		t.Errorf("expected 1 draft achievement in 2020-GANJIL, got %d %+v", stats.TotalAchievements, stats.ByStatus)
+	}
+
+	results, total, err := svc.GetAllAchievementsWithFilter(ctx, model.AchievementFilter{AcademicPeriod: current, Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 1 || len(results) != 1 || results[0].Title != "Undated, created now" {
+		t.Errorf("expected the filtered list to hold only the undated achievement, got %d", total)
+	}
+}
+
+// Test Every Way of Dating an Achievement Lands in the Same Period
+func TestAchievementService_PeriodFilterDateFormats(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	past, err := svc.SaveAcademicPeriod("2020-GANJIL", model.SaveAcademicPeriodRequest{StartDate: "2020-08-01", EndDate: "2021-01-31"})
+	if err != nil {
+		t.Fatalf("failed to save period: %v", err)
+	}
+
+	// Event dates saved before validation required YYYY-MM-DD may be RFC3339
+	legacy := model.Achievement{Details: model.AchievementDetails{EventDate: "2020-11-05T10:00:00+07:00"}, CreatedAt: time.Now()}
+	if !past.Contains(legacy.ReportDate()) {
+		t.Errorf("expected an RFC3339 event date to be dated in 2020-GANJIL, got %s", legacy.ReportDate())
+	}
+
+	if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "organization",
+		Title:           "Ketua HIMA",
+		Details: model.AchievementDetails{OrganizationName: "HIMA", Position: "Ketua", Period: &model.PeriodDetail{
+			Start: time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2021, time.August, 31, 0, 0, 0, 0, time.UTC),
+		}},
+	}); err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+
+	report, err := svc.GetStudentReportForPeriod(ctx, "student-1", past)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalAchievements != 1 {
+		t.Errorf("expected the period-dated achievement in 2020-GANJIL, got %d", report.TotalAchievements)
+	}
+
+	for _, achievement := range report.Achievements {
+		if date := achievement.ReportDate(); !past.Contains(date) {
+			t.Errorf("expected %q to be dated in 2020-GANJIL, got %s", achievement.Title, date)
+		}
+	}
+}
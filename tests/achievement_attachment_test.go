@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/app/storage"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+
+// Test Uploading and Downloading Stored Attachments
+func TestAchievementService_StoreAttachment(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetAttachmentStorage(storage.NewLocalStorage(t.TempDir()))
+	svc.SetAttachmentMaxBytes(1024)
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "other",
+		Title:           "Volunteer Award",
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+
+	if _, err := svc.StoreAttachment(ctx, refID, "student-2", "award.pdf", bytes.NewReader(pdfContent)); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected unauthorized for another student, got %v", err)
+	}
+	if _, err := svc.StoreAttachment(ctx, refID, "student-1", "award.pdf", bytes.NewReader([]byte("plain text pretending to be a pdf"))); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected sniffed text/plain to be rejected, got %v", err)
+	}
+	if _, err := svc.StoreAttachment(ctx, refID, "student-1", "big.pdf", bytes.NewReader(append(pdfContent, make([]byte, 1024)...))); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected oversized file to be rejected, got %v", err)
+	}
+
+	attachment, err := svc.StoreAttachment(ctx, refID, "student-1", "../award.pdf", bytes.NewReader(pdfContent))
+	if err != nil {
+		t.Fatalf("failed to store attachment: %v", err)
+	}
+	sum := sha256.Sum256(pdfContent)
+	if attachment.FileName != "award.pdf" || attachment.FileType != "application/pdf" || attachment.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected attachment metadata: %+v", attachment)
+	}
+	if attachment.UploadedAt.IsZero() {
+		t.Error("expected the returned attachment to carry its upload time")
+	}
+
+	if _, _, err := svc.OpenAttachment(ctx, refID, attachment.ID, []string{"student-2"}); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected unauthorized download, got %v", err)
+	}
+
+	_, content, err := svc.OpenAttachment(ctx, refID, attachment.ID, []string{"student-1"})
+	if err != nil {
+		t.Fatalf("failed to open attachment: %v", err)
+	}
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	if !bytes.Equal(data, pdfContent) {
+		t.Errorf("downloaded content differs from upload")
+	}
+
+	// Evidence is frozen once the achievement is submitted
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+	if _, err := svc.StoreAttachment(ctx, refID, "student-1", "late.pdf", bytes.NewReader(pdfContent)); !errors.Is(err, service.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition uploading to a submitted achievement, got %v", err)
+	}
+}
+
+// Test Replacing and Deleting Attachments
+func TestAchievementService_ReplaceAndDeleteAttachment(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	store := storage.NewLocalStorage(t.TempDir())
+	svc.SetAttachmentStorage(store)
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "other",
+		Title:           "Volunteer Award",
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+
+	original, err := svc.StoreAttachment(ctx, refID, "student-1", "scan.pdf", bytes.NewReader(pdfContent))
+	if err != nil {
+		t.Fatalf("failed to store attachment: %v", err)
+	}
+
+	sharper := append(append([]byte{}, pdfContent...), []byte("% rescanned\n")...)
+	replaced, err := svc.ReplaceAttachment(ctx, refID, "student-1", original.ID, "scan-v2.pdf", bytes.NewReader(sharper))
+	if err != nil {
+		t.Fatalf("failed to replace attachment: %v", err)
+	}
+	if replaced.ID != original.ID || replaced.SHA256 == original.SHA256 {
+		t.Errorf("expected same ID with new checksum, got %+v", replaced)
+	}
+	if replaced.UploadedAt.Before(original.UploadedAt) || replaced.UploadedAt.IsZero() {
+		t.Errorf("expected the replacement to carry its own upload time, got %v after %v", replaced.UploadedAt, original.UploadedAt)
+	}
+	if _, err := store.Open(ctx, original.StorageKey); !errors.Is(err, storage.ErrObjectNotFound) {
+		t.Errorf("expected replaced blob to be removed, got %v", err)
+	}
+
+	if err := svc.DeleteAttachment(ctx, refID, "student-2", original.ID); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected unauthorized delete, got %v", err)
+	}
+
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+	if err := svc.DeleteAttachment(ctx, refID, "student-1", original.ID); !errors.Is(err, service.ErrInvalidTransition) {
+		t.Errorf("expected delete to be refused once submitted, got %v", err)
+	}
+	if _, err := svc.WithdrawAchievement(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to withdraw achievement: %v", err)
+	}
+
+	if err := svc.DeleteAttachment(ctx, refID, "student-1", original.ID); err != nil {
+		t.Fatalf("failed to delete attachment: %v", err)
+	}
+	if _, err := store.Open(ctx, replaced.StorageKey); !errors.Is(err, storage.ErrObjectNotFound) {
+		t.Errorf("expected deleted blob to be removed, got %v", err)
+	}
+
+	result, err := svc.GetAchievementByID(ctx, refID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Attachments) != 0 {
+		t.Errorf("expected no attachments left, got %d", len(result.Attachments))
+	}
+}
+
+// Test Only the Team Leader Changes a Team's Attachments
+func TestAchievementService_TeamAttachments(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetAttachmentStorage(storage.NewLocalStorage(t.TempDir()))
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refs, err := mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	if err != nil || len(refs) != 2 || refs[1].StudentID != "student-2" {
+		t.Fatalf("expected a reference for student-2, got %+v (%v)", refs, err)
+	}
+	memberRefID := refs[1].ID
+
+	if _, err := svc.StoreAttachment(ctx, memberRefID, "student-2", "award.pdf", bytes.NewReader(pdfContent)); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for a member uploading, got %v", err)
+	}
+
+	attachment, err := svc.StoreAttachment(ctx, created.ID.Hex(), "student-1", "award.pdf", bytes.NewReader(pdfContent))
+	if err != nil {
+		t.Fatalf("failed to store attachment: %v", err)
+	}
+	if _, err := svc.ReplaceAttachment(ctx, memberRefID, "student-2", attachment.ID, "award.pdf", bytes.NewReader(pdfContent)); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for a member replacing, got %v", err)
+	}
+	if err := svc.DeleteAttachment(ctx, memberRefID, "student-2", attachment.ID); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for a member deleting, got %v", err)
+	}
+}
+
+// Test an Unsupported Storage Driver Is Refused Instead of Falling Back to Local Disk
+func TestStorage_UnsupportedDriver(t *testing.T) {
+	t.Setenv("STORAGE_DRIVER", "s3")
+
+	if err := storage.CheckEnv(); err == nil {
+		t.Errorf("expected an error for an unsupported storage driver")
+	}
+	if err := storage.FromEnv().Put(context.Background(), "a/b.pdf", bytes.NewReader([]byte("x"))); err == nil {
+		t.Errorf("expected the storage to refuse writes with an unsupported driver")
+	}
+
+	t.Setenv("STORAGE_DRIVER", storage.DriverLocal)
+	if err := storage.CheckEnv(); err != nil {
+		t.Errorf("unexpected error for the local driver: %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Review Comment Threads
+func TestAchievementService_Comments(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Programming Contest",
+		Details:         model.AchievementDetails{CompetitionName: "Programming Contest", CompetitionLevel: "national"},
+		Points:          100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+
+	// Change requests need an achievement under review
+	_, err = svc.AddComment(ctx, refID, "lecturer-user", "lecturer", "", model.CreateCommentRequest{
+		Body: "Please attach the certificate",
+		Kind: service.CommentKindChangeRequest,
+	})
+	if err == nil {
+		t.Errorf("expected error requesting changes on a draft")
+	}
+
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+
+	request, err := svc.AddComment(ctx, refID, "lecturer-user", "lecturer", "", model.CreateCommentRequest{
+		Body: "Please attach the certificate",
+		Kind: service.CommentKindChangeRequest,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.AddComment(ctx, refID, "other-user", "student", "student-2", model.CreateCommentRequest{Body: "Hi"}); err == nil {
+		t.Errorf("expected error commenting on someone else's achievement")
+	}
+	if _, err := svc.AddComment(ctx, refID, "student-user", "student", "student-1", model.CreateCommentRequest{
+		Body: "Looks fine to me",
+		Kind: service.CommentKindChangeRequest,
+	}); err == nil {
+		t.Errorf("expected error when a student requests changes")
+	}
+
+	reply, err := svc.AddComment(ctx, refID, "student-user", "student", "student-1", model.CreateCommentRequest{
+		Body:     "Uploaded, thanks",
+		ParentID: &request.ID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Requesting changes sends the achievement back to draft without rejecting it
+	achievement, err := svc.GetAchievementByID(ctx, refID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if achievement.Status != service.StatusDraft || achievement.RejectionNote != nil {
+		t.Errorf("expected an unrejected draft, got %s", achievement.Status)
+	}
+	last := achievement.StatusHistory[len(achievement.StatusHistory)-1]
+	if last.ToStatus != service.StatusDraft || last.ActorID != "lecturer-user" || last.Note == nil || *last.Note != "Please attach the certificate" {
+		t.Errorf("expected the change request to be recorded in the history, got %+v", last)
+	}
+	if achievement.Comments.Total != 2 || achievement.Comments.Unresolved != 1 {
+		t.Errorf("expected 2 comments with 1 unresolved thread, got %+v", achievement.Comments)
+	}
+
+	threads, err := svc.GetComments(ctx, refID, "student-user", "student", "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 1 || len(threads[0].Replies) != 1 {
+		t.Fatalf("expected 1 thread with 1 reply, got %+v", threads)
+	}
+
+	// Resolving through a reply resolves the whole thread
+	if err := svc.ResolveComment(ctx, refID, reply.ID, "lecturer-user", "lecturer", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list, err := svc.GetAchievementsByStudentID(ctx, "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].Comments.Unresolved != 0 {
+		t.Errorf("expected no unresolved threads after resolving, got %+v", list[0].Comments)
+	}
+}
+
+// Test Only the Advisor or Current Approver Takes Part in the Review
+func TestAchievementService_CommentReviewers(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetVerificationAuthorizer(stubAuthorizer{
+		advisees:  map[string]string{"student-1": "lecturer-1"},
+		approvers: map[string]string{"department": "head-1"},
+	})
+
+	err := svc.SaveApprovalChain(model.ApprovalChain{
+		AchievementType:  "competition",
+		CompetitionLevel: "national",
+		Stages: []model.ApprovalStage{
+			{Name: "advisor", Permission: "achievement:verify", AdvisorOnly: true},
+			{Name: "department", Permission: "achievement:approve_department"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to save approval chain: %v", err)
+	}
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+
+	if _, err := svc.AddComment(ctx, refID, "lecturer-2", service.RoleLecturer, "", model.CreateCommentRequest{Body: "Hi"}); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for an unrelated lecturer, got %v", err)
+	}
+	if _, err := svc.AddComment(ctx, refID, "head-1", service.RoleLecturer, "", model.CreateCommentRequest{Body: "Hi"}); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for a later stage's approver, got %v", err)
+	}
+	if _, err := svc.AddComment(ctx, refID, "lecturer-1", service.RoleLecturer, "", model.CreateCommentRequest{Body: "Looks good"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.GetComments(ctx, refID, "lecturer-2", service.RoleLecturer, ""); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized reading comments as an unrelated lecturer, got %v", err)
+	}
+	if threads, err := svc.GetComments(ctx, refID, "lecturer-1", service.RoleLecturer, ""); err != nil || len(threads) != 1 {
+		t.Errorf("expected the advisor to read 1 thread, got %d (%v)", len(threads), err)
+	}
+
+	if _, err := svc.VerifyAchievement(ctx, refID, "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Once the advisor signed off, the department approver can send it back for changes
+	if _, err := svc.AddComment(ctx, refID, "head-1", service.RoleLecturer, "", model.CreateCommentRequest{
+		Body: "Please attach the certificate",
+		Kind: service.CommentKindChangeRequest,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	achievement, err := svc.GetAchievementByID(ctx, refID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if achievement.Status != service.StatusDraft {
+		t.Errorf("expected the change request to reopen the achievement as a draft, got %s", achievement.Status)
+	}
+	if _, err := svc.UpdateAchievement(ctx, refID, "student-1", model.UpdateAchievementRequest{
+		Title:   "Gemastik 2026",
+		Details: model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+	}); err != nil {
+		t.Errorf("expected the student to be able to revise the achievement, got %v", err)
+	}
+}
+
+// Test a Change Request Whose Comment Cannot Be Saved Leaves the Achievement Under Review
+func TestAchievementService_ChangeRequestIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+
+	mockRepo.FailComments(errors.New("connection reset"))
+	if _, err := svc.AddComment(ctx, refID, "lecturer-user", service.RoleLecturer, "", model.CreateCommentRequest{
+		Body: "Please attach the certificate",
+		Kind: service.CommentKindChangeRequest,
+	}); err == nil {
+		t.Fatalf("expected the change request to fail when its comment cannot be saved")
+	}
+
+	achievement, err := svc.GetAchievementByID(ctx, refID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if achievement.Status != service.StatusSubmitted {
+		t.Errorf("expected the achievement to stay submitted, got %s", achievement.Status)
+	}
+	if last := achievement.StatusHistory[len(achievement.StatusHistory)-1]; last.ToStatus != service.StatusSubmitted {
+		t.Errorf("expected no change request in the history, got %+v", last)
+	}
+
+	mockRepo.FailComments(nil)
+	request, err := svc.AddComment(ctx, refID, "lecturer-user", service.RoleLecturer, "", model.CreateCommentRequest{
+		Body: "Please attach the certificate",
+		Kind: service.CommentKindChangeRequest,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	threads, err := svc.GetComments(ctx, refID, "lecturer-user", service.RoleLecturer, "")
+	if err != nil || len(threads) != 1 || threads[0].ID != request.ID {
+		t.Errorf("expected the change request to be saved as the only thread, got %+v (%v)", threads, err)
+	}
+}
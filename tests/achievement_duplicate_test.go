@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Duplicate Achievement Detection
+func TestAchievementService_DuplicateDetection(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	first, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik XV - Juara 1",
+		Details:         model.AchievementDetails{CompetitionName: "GEMASTIK", CompetitionLevel: "national", EventDate: "2025-10-20"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if len(first.Duplicates) != 0 {
+		t.Errorf("expected no duplicates for the first achievement, got %+v", first.Duplicates)
+	}
+
+	// A teammate logs the same competition under a different title
+	teammate, err := svc.CreateAchievement(ctx, "student-2", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Winning Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national", EventDate: "2025-10-20"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if len(teammate.Duplicates) != 1 || teammate.Duplicates[0].Reasons[0] != service.DuplicateReasonCompetition {
+		t.Fatalf("expected a competition duplicate, got %+v", teammate.Duplicates)
+	}
+
+	// Another competition on the same day is not a candidate
+	if _, err := svc.CreateAchievement(ctx, "student-3", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Campus Hackathon",
+		Details:         model.AchievementDetails{CompetitionName: "Campus Hackathon", CompetitionLevel: "local", EventDate: "2025-10-20"},
+	}); err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	candidates, err := mockRepo.FindDuplicateCandidatesMongo(ctx, &teammate.Achievement)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].ID != first.ID {
+		t.Errorf("expected only the first achievement as a candidate, got %d candidates", len(candidates))
+	}
+
+	// The same student logs it twice with punctuation differences
+	again, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "gemastik xv juara 1",
+		Details:         model.AchievementDetails{CompetitionName: "gemastik xv juara 1", CompetitionLevel: "national"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if len(again.Duplicates) != 1 || again.Duplicates[0].AchievementID != first.ID.Hex() {
+		t.Errorf("expected a title duplicate of the first achievement, got %+v", again.Duplicates)
+	}
+
+	// The earlier copy is flagged in the advisor's queue too
+	advisees, err := svc.GetAchievementsForAdvisees(ctx, []string{"student-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, achievement := range advisees {
+		if achievement.ID == first.ID && len(achievement.Duplicates) != 2 {
+			t.Errorf("expected the first achievement to be flagged twice, got %+v", achievement.Duplicates)
+		}
+	}
+
+	if err := svc.DeleteAchievement(ctx, again.ID.Hex(), "student-1"); err != nil {
+		t.Fatalf("failed to delete achievement: %v", err)
+	}
+	result, err := svc.GetAchievementByID(ctx, first.ID.Hex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Duplicates) != 1 {
+		t.Errorf("expected deleted copy to be cleared from flags, got %+v", result.Duplicates)
+	}
+}
+
+// Test Achievements Saved Before Titles Were Normalized Are Still Matched by Title
+func TestAchievementService_DuplicateDetectionLegacyTitle(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	legacyID, err := mockRepo.CreateAchievementMongo(ctx, &model.Achievement{
+		StudentID:       "student-1",
+		AchievementType: "organization",
+		Title:           "Ketua BEM Fakultas",
+	})
+	if err != nil {
+		t.Fatalf("failed to create legacy achievement: %v", err)
+	}
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "organization",
+		Title:           "ketua bem fakultas",
+		Details:         model.AchievementDetails{OrganizationName: "BEM Fakultas", Position: "Ketua"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if len(created.Duplicates) != 1 || created.Duplicates[0].AchievementID != legacyID || created.Duplicates[0].Reasons[0] != service.DuplicateReasonTitle {
+		t.Errorf("expected a title duplicate of the legacy achievement, got %+v", created.Duplicates)
+	}
+
+	legacy, err := mockRepo.GetAchievementMongo(ctx, legacyID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(legacy.Duplicates) != 1 || legacy.Duplicates[0].AchievementID != created.ID.Hex() {
+		t.Errorf("expected the legacy achievement to be flagged too, got %+v", legacy.Duplicates)
+	}
+}
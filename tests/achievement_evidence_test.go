@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/app/storage"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Evidence Requirements Checked at Submit
+func TestAchievementService_EvidenceRequirements(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetAttachmentStorage(storage.NewLocalStorage(t.TempDir()))
+
+	if _, err := svc.SaveAchievementType(model.AchievementTypeDefinition{
+		Name:                 "publication",
+		RequiredFields:       []string{"publicationType", "publicationTitle"},
+		EvidenceRequirements: []model.EvidenceRequirement{{Name: "proof", FileTypes: []string{"text/html"}}},
+	}); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected unsupported evidence file type to be rejected, got %v", err)
+	}
+
+	if _, err := svc.SaveAchievementType(model.AchievementTypeDefinition{
+		Name:           "publication",
+		RequiredFields: []string{"publicationType", "publicationTitle"},
+		IsActive:       true,
+		EvidenceRequirements: []model.EvidenceRequirement{
+			{Name: "publication", Description: "DOI or the published PDF", FileTypes: []string{"application/pdf"}, DetailFields: []string{"doi"}},
+		},
+	}); err != nil {
+		t.Fatalf("failed to save achievement type: %v", err)
+	}
+
+	create := func(details model.AchievementDetails) string {
+		created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "publication",
+			Title:           "Paper",
+			Details:         details,
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		return created.ID.Hex()
+	}
+	details := model.AchievementDetails{PublicationType: "journal", PublicationTitle: "On Graphs"}
+
+	withoutEvidence := create(details)
+	_, err := svc.SubmitForVerification(ctx, withoutEvidence, "student-1")
+	var evidenceErr *service.MissingEvidenceError
+	if !errors.As(err, &evidenceErr) || len(evidenceErr.Missing) != 1 || evidenceErr.Missing[0].Name != "publication" {
+		t.Fatalf("expected missing publication evidence, got %v", err)
+	}
+
+	if _, err := svc.StoreAttachment(ctx, withoutEvidence, "student-1", "paper.pdf", bytes.NewReader(pdfContent)); err != nil {
+		t.Fatalf("failed to store attachment: %v", err)
+	}
+	if _, err := svc.SubmitForVerification(ctx, withoutEvidence, "student-1"); err != nil {
+		t.Errorf("expected PDF to satisfy the requirement, got %v", err)
+	}
+
+	details.DOI = "10.1000/xyz123"
+	if _, err := svc.SubmitForVerification(ctx, create(details), "student-1"); err != nil {
+		t.Errorf("expected DOI to satisfy the requirement, got %v", err)
+	}
+}
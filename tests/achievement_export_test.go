@@ -0,0 +1,229 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"student-report/utils"
+	"testing"
+)
+
+// Test Exporting Every Matching Achievement as Flat Rows
+func TestAchievementService_ExportAchievements(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national", Rank: 1},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2", Role: "programmer"}},
+	}); err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	certification, err := svc.CreateAchievement(ctx, "student-3", model.CreateAchievementRequest{
+		AchievementType: "certification",
+		Title:           "Java SE Programmer",
+		Tags:            []string{"java", "oracle"},
+		Details:         model.AchievementDetails{CertificationName: "Java SE Programmer", IssuedBy: "Oracle", CustomFields: map[string]interface{}{"score": 92, "attempt": 1}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if _, err := svc.SubmitForVerification(ctx, certification.ID.Hex(), "student-3"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+	if _, err := svc.VerifyAchievement(ctx, certification.ID.Hex(), "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+		t.Fatalf("failed to verify achievement: %v", err)
+	}
+
+	export := func(filter model.AchievementFilter) []map[string]string {
+		var rows []map[string]string
+		err := svc.ExportAchievements(ctx, filter, func(record []string) error {
+			if len(record) != len(service.AchievementExportColumns) {
+				t.Fatalf("expected %d columns, got %d", len(service.AchievementExportColumns), len(record))
+			}
+			row := make(map[string]string)
+			for i, column := range service.AchievementExportColumns {
+				row[column] = record[i]
+			}
+			rows = append(rows, row)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return rows
+	}
+
+	// Every row regardless of page, one per team member
+	if rows := export(model.AchievementFilter{Page: 3, Limit: 1}); len(rows) != 3 {
+		t.Errorf("expected 3 rows for 2 achievements with a team of 2, got %d", len(rows))
+	}
+
+	studentID := "student-2"
+	rows := export(model.AchievementFilter{StudentID: &studentID})
+	if len(rows) != 1 || rows[0]["Student ID"] != "student-2" || rows[0]["Team Role"] != "programmer" || rows[0]["Competition Level"] != "national" || rows[0]["Rank"] != "1" {
+		t.Errorf("expected only student-2's row of the team achievement, got %+v", rows)
+	}
+
+	status := service.StatusVerified
+	rows = export(model.AchievementFilter{Status: &status})
+	if len(rows) != 1 {
+		t.Fatalf("expected only the verified certification, got %d rows", len(rows))
+	}
+	row := rows[0]
+	if row["Verifier ID"] != "lecturer-1" || row["Verifier Name"] != "Test Lecturer lecturer-1" || row["Verified At"] == "" {
+		t.Errorf("expected the verifier to be exported, got %+v", row)
+	}
+	if row["Student Name"] != "Test Student" || row["NIM"] != "123456" || row["Issued By"] != "Oracle" {
+		t.Errorf("expected student and certification details to be flattened, got %+v", row)
+	}
+	if row["Tags"] != "java, oracle" || row["Custom Fields"] != "attempt: 1; score: 92" {
+		t.Errorf("expected tags and custom fields as text, got %q and %q", row["Tags"], row["Custom Fields"])
+	}
+}
+
+// Test the XLSX Writer Produces a Readable Workbook
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := utils.NewXLSXWriter(&buf, "Achievements")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.WriteHeader([]string{"Title", "Level"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Write([]string{"R&D <Expo>", "national"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, exists := parts[name]; !exists {
+			t.Errorf("expected workbook part %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, `<c r="A1" t="inlineStr" s="1">`) || !strings.Contains(sheet, `<c r="B2" t="inlineStr">`) {
+		t.Errorf("expected a bold header and addressed cells, got %s", sheet)
+	}
+	if !strings.Contains(sheet, "R&amp;D &lt;Expo&gt;") || !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Errorf("expected escaped cell text in a closed sheet, got %s", sheet)
+	}
+
+	for index, expected := range map[int]string{0: "A", 25: "Z", 26: "AA", 40: "AO", 701: "ZZ", 702: "AAA"} {
+		if name := utils.XLSXColumnName(index); name != expected {
+			t.Errorf("column %d: expected %s, got %s", index, expected, name)
+		}
+	}
+}
+
+// Test Exports Spanning Several Pages of Equal Sort Keys
+func TestAchievementService_ExportAchievementsAcrossPages(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	// More than one export page, all tied on the sort field
+	const count = 1201
+	for i := 0; i < count; i++ {
+		if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "competition",
+			Title:           fmt.Sprintf("Lomba %d", i),
+			Points:          50,
+			Details:         model.AchievementDetails{CompetitionName: "Lomba", CompetitionLevel: "regional"},
+		}); err != nil {
+			t.Fatalf("failed to create achievement %d: %v", i, err)
+		}
+	}
+
+	sortBy := "points"
+	seen := make(map[string]bool)
+	err := svc.ExportAchievements(ctx, model.AchievementFilter{SortBy: &sortBy}, func(record []string) error {
+		if seen[record[0]] {
+			t.Errorf("reference %s exported twice", record[0])
+		}
+		seen[record[0]] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != count {
+		t.Errorf("expected %d distinct rows, got %d", count, len(seen))
+	}
+}
+
+// Test Student Text Cannot Run as a Spreadsheet Formula
+func TestAchievementService_ExportAchievementsEscapesFormulas(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           `=HYPERLINK("http://evil.example","Gemastik")`,
+		Description:     "+1 juara",
+		Tags:            []string{"@SUM(A1)"},
+		Details: model.AchievementDetails{
+			CompetitionName:  "-2+3",
+			CompetitionLevel: "national",
+			Organizer:        "\tKemendikbud",
+			CustomFields:     map[string]interface{}{"note": "x"},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+
+	var record []string
+	if err := svc.ExportAchievements(ctx, model.AchievementFilter{}, func(r []string) error {
+		record = r
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	row := make(map[string]string)
+	for i, column := range service.AchievementExportColumns {
+		row[column] = record[i]
+	}
+	expected := map[string]string{
+		"Title":            `'=HYPERLINK("http://evil.example","Gemastik")`,
+		"Description":      "'+1 juara",
+		"Tags":             "'@SUM(A1)",
+		"Competition Name": "'-2+3",
+		"Organizer":        "'\tKemendikbud",
+		"Custom Fields":    "note: x",
+		"Student ID":       "student-1",
+	}
+	for column, value := range expected {
+		if row[column] != value {
+			t.Errorf("%s: expected %q, got %q", column, value, row[column])
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/app/storage"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Revisions and Diff
+func TestAchievementService_RevisionDiff(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetAttachmentStorage(storage.NewLocalStorage(t.TempDir()))
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Programming Contest",
+		Points:          100,
+		Tags:            []string{"programming", "regional"},
+		Details: model.AchievementDetails{
+			CompetitionName:  "ICPC",
+			CompetitionLevel: "regional",
+			Rank:             3,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+
+	_, err = svc.UpdateAchievement(ctx, refID, "student-1", model.UpdateAchievementRequest{
+		Title:  "Programming Contest",
+		Points: 100,
+		Tags:   []string{"programming", "national"},
+		Details: model.AchievementDetails{
+			CompetitionName:  "ICPC",
+			CompetitionLevel: "national",
+			Rank:             3,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to update achievement: %v", err)
+	}
+
+	if _, err := svc.StoreAttachment(ctx, refID, "student-1", "certificate.pdf", bytes.NewReader(pdfContent)); err != nil {
+		t.Fatalf("failed to upload attachment: %v", err)
+	}
+
+	revisions, err := svc.GetRevisions(ctx, refID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revisions))
+	}
+
+	diff, err := svc.DiffRevisions(ctx, refID, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The level change also rescores the achievement
+	if len(diff.Changes) != 2 || diff.Changes[0].Field != "points" || diff.Changes[1].Field != "details.competitionLevel" {
+		t.Errorf("expected points and details.competitionLevel to change, got %+v", diff.Changes)
+	}
+	if len(diff.TagsAdded) != 1 || diff.TagsAdded[0] != "national" {
+		t.Errorf("expected tag national to be added, got %v", diff.TagsAdded)
+	}
+	if len(diff.TagsRemoved) != 1 || diff.TagsRemoved[0] != "regional" {
+		t.Errorf("expected tag regional to be removed, got %v", diff.TagsRemoved)
+	}
+	if len(diff.AttachmentsAdded) != 1 {
+		t.Errorf("expected 1 attachment added, got %d", len(diff.AttachmentsAdded))
+	}
+
+	if _, err := svc.DiffRevisions(ctx, refID, 1, 9); err == nil {
+		t.Errorf("expected error for unknown revision")
+	}
+}
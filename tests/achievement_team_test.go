@@ -0,0 +1,304 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Team Achievements Get a Reference and Verification per Member
+func TestAchievementService_TeamAchievement(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetVerificationAuthorizer(stubAuthorizer{advisees: map[string]string{
+		"student-1": "lecturer-1",
+		"student-2": "lecturer-2",
+		"student-3": "lecturer-3",
+	}})
+
+	if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}, {StudentID: "student-2"}},
+	}); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected ErrValidation for a repeated member, got %v", err)
+	}
+
+	mockRepo.RemoveStudent("student-9")
+	_, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}, {StudentID: "student-9"}},
+	})
+	var validationErr *service.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != "teamMembers[1].studentId" {
+		t.Errorf("expected a validation error for the unknown member only, got %v", err)
+	}
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national", Rank: 1},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2", Role: "programmer"}, {StudentID: "student-3"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if len(created.TeamMembers) != 3 || created.TeamMembers[0].StudentID != "student-1" || created.TeamMembers[0].Role != service.TeamRoleLeader {
+		t.Fatalf("expected the creator to lead a team of 3, got %+v", created.TeamMembers)
+	}
+	if created.Points != 70 {
+		t.Errorf("expected every member to earn 70 points by default, got %d", created.Points)
+	}
+
+	refs, err := mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	if err != nil || len(refs) != 3 {
+		t.Fatalf("expected 3 references, got %d (%v)", len(refs), err)
+	}
+	member := refs[1]
+	if member.StudentID != "student-2" {
+		t.Fatalf("expected the second reference to belong to student-2, got %s", member.StudentID)
+	}
+
+	// Only the leader drives the shared document
+	if _, err := svc.SubmitForVerification(ctx, member.ID, "student-2"); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for a member submitting, got %v", err)
+	}
+	if _, err := svc.SubmitForVerification(ctx, created.ID.Hex(), "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+	refs, _ = mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	for _, ref := range refs {
+		if ref.Status != service.StatusSubmitted {
+			t.Errorf("expected %s's reference to be submitted, got %s", ref.StudentID, ref.Status)
+		}
+	}
+
+	// Each advisor verifies their own student's participation
+	if _, err := svc.VerifyAchievement(ctx, created.ID.Hex(), "lecturer-2", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected ErrValidation verifying a team achievement by document ID, got %v", err)
+	}
+	if _, err := svc.VerifyAchievement(ctx, member.ID, "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for another member's advisor, got %v", err)
+	}
+	verified, err := svc.VerifyAchievement(ctx, member.ID, "lecturer-2", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verified.Status != service.StatusVerified || verified.ReferenceID != member.ID {
+		t.Errorf("expected student-2's reference to be verified, got %s on %s", verified.Status, verified.ReferenceID)
+	}
+
+	note := "Bukti keikutsertaan tidak ada"
+	if _, err := svc.VerifyAchievement(ctx, refs[2].ID, "lecturer-3", service.RoleLecturer, model.VerifyAchievementRequest{Action: "reject", Note: &note}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	leader, err := svc.GetAchievementByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if leader.Status != service.StatusSubmitted {
+		t.Errorf("expected the leader's reference to stay submitted, got %s", leader.Status)
+	}
+
+	report, err := svc.GetStudentReport(ctx, "student-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalAchievements != 1 || report.TotalPoints != 70 {
+		t.Errorf("expected student-2 to be credited 70 points for 1 achievement, got %d for %d", report.TotalPoints, report.TotalAchievements)
+	}
+}
+
+// Test Team Points Split Policy
+func TestAchievementService_TeamPointsSplit(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national", Rank: 1},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}, {StudentID: "student-3"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+
+	if _, err := svc.SavePointsRuleSet(nil, nil, "halve", "admin-1"); err == nil {
+		t.Errorf("expected error saving an unknown team policy")
+	}
+
+	defaults, err := svc.GetPointsRuleSet()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.SavePointsRuleSet(defaults.Rules, nil, "split", "admin-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changed, err := svc.RecomputeAllPoints(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("expected the shared document to be rescored once, got %d", changed)
+	}
+
+	refs, _ := mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	expected := []int{24, 23, 23}
+	total := 0
+	for i, ref := range refs {
+		achievement, err := svc.GetAchievementByID(ctx, ref.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if achievement.Points != expected[i] {
+			t.Errorf("expected %s to get %d points, got %d", ref.StudentID, expected[i], achievement.Points)
+		}
+		total += achievement.Points
+	}
+	if total != 70 {
+		t.Errorf("expected the shares to add up to 70, got %d", total)
+	}
+}
+
+// Test a Change Request on a Team Member Is Resubmitted by the Leader
+func TestAchievementService_TeamMemberChangeRequest(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetVerificationAuthorizer(stubAuthorizer{advisees: map[string]string{
+		"student-1": "lecturer-1",
+		"student-2": "lecturer-2",
+	}})
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national", Rank: 1},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if _, err := svc.SubmitForVerification(ctx, created.ID.Hex(), "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+
+	refs, _ := mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	member := refs[1]
+	if _, err := svc.AddComment(ctx, member.ID, "lecturer-2", service.RoleLecturer, "", model.CreateCommentRequest{
+		Body: "Please confirm your role in the team",
+		Kind: service.CommentKindChangeRequest,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the member's own participation goes back to draft
+	refs, _ = mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	if refs[0].Status != service.StatusSubmitted || refs[1].Status != service.StatusDraft {
+		t.Fatalf("expected leader submitted and member draft, got %s and %s", refs[0].Status, refs[1].Status)
+	}
+
+	if _, err := svc.ResubmitAchievement(ctx, created.ID.Hex(), "student-1"); err != nil {
+		t.Fatalf("expected the leader to resubmit the member's participation, got %v", err)
+	}
+	refs, _ = mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	if refs[1].Status != service.StatusSubmitted || refs[1].SubmittedAt == nil {
+		t.Errorf("expected the member to be back under review, got %s", refs[1].Status)
+	}
+
+	if _, err := svc.ResubmitAchievement(ctx, created.ID.Hex(), "student-1"); !errors.Is(err, service.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition with nothing left to resubmit, got %v", err)
+	}
+}
+
+// Test a Teammate's Verified Participation Locks the Shared Document
+func TestAchievementService_TeamVerifiedLocksDocument(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetVerificationAuthorizer(stubAuthorizer{advisees: map[string]string{
+		"student-1": "lecturer-1",
+		"student-2": "lecturer-2",
+	}})
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national", Rank: 1},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if _, err := svc.SubmitForVerification(ctx, created.ID.Hex(), "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+
+	refs, _ := mockRepo.GetAchievementReferencesByMongoID(created.ID.Hex())
+	if _, err := svc.VerifyAchievement(ctx, refs[1].ID, "lecturer-2", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddComment(ctx, refs[0].ID, "lecturer-1", service.RoleLecturer, "", model.CreateCommentRequest{
+		Body: "Please add the certificate number",
+		Kind: service.CommentKindChangeRequest,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The leader is back in draft, but student-2's verified content must not change or disappear
+	if _, err := svc.UpdateAchievement(ctx, refs[0].ID, "student-1", model.UpdateAchievementRequest{
+		Title:   "Gemastik 2026",
+		Details: model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "international", Rank: 1},
+	}); !errors.Is(err, service.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition editing a document a teammate has verified, got %v", err)
+	}
+	if err := svc.DeleteAchievement(ctx, refs[0].ID, "student-1"); !errors.Is(err, service.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition deleting a document a teammate has verified, got %v", err)
+	}
+
+	member, err := svc.GetAchievementByID(ctx, refs[1].ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.Status != service.StatusVerified || member.Title != "Gemastik" || member.Points != 70 {
+		t.Errorf("expected student-2's verified achievement to be untouched, got %s %q %d", member.Status, member.Title, member.Points)
+	}
+}
+
+// Test a Team Achievement Is Created Whole or Not at All
+func TestAchievementService_TeamCreateRollsBack(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	mockRepo.FailReferences("student-3", errors.New("connection reset"))
+	_, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Gemastik",
+		Details:         model.AchievementDetails{CompetitionName: "Gemastik", CompetitionLevel: "national"},
+		TeamMembers:     []model.TeamMember{{StudentID: "student-2"}, {StudentID: "student-3"}},
+	})
+	if err == nil {
+		t.Fatal("expected creation to fail when a member's reference cannot be created")
+	}
+
+	refs, _ := mockRepo.GetAllAchievementReferences()
+	if len(refs) != 0 {
+		t.Errorf("expected no references to be left behind, got %+v", refs)
+	}
+	achievements, total, _ := mockRepo.GetAchievementsWithFilter(ctx, model.AchievementFilter{})
+	if total != 0 {
+		t.Errorf("expected the document to be removed, got %+v", achievements)
+	}
+}
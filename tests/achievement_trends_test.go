@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Achievement Trends over Time
+func TestAchievementService_GetTrends(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	create := func(studentID string, req model.CreateAchievementRequest) string {
+		created, err := svc.CreateAchievement(ctx, studentID, req)
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		return created.ID.Hex()
+	}
+	competition := func(name, level, eventDate string) model.CreateAchievementRequest {
+		return model.CreateAchievementRequest{
+			AchievementType: "competition",
+			Title:           name,
+			Details:         model.AchievementDetails{CompetitionName: name, CompetitionLevel: level, EventDate: eventDate},
+		}
+	}
+
+	submit := func(id, studentID string) {
+		if _, err := svc.SubmitForVerification(ctx, id, studentID); err != nil {
+			t.Fatalf("failed to submit achievement: %v", err)
+		}
+	}
+
+	submit(create("student-1", competition("GEMASTIK", "national", "2025-09-10")), "student-1")
+	submit(create("student-1", competition("Hackathon Kota", "regional", "2025-12-01")), "student-1")
+	submit(create("student-2", competition("ICPC Asia", "international", "2026-03-15")), "student-2")
+	submit(create("student-1", model.CreateAchievementRequest{
+		AchievementType: "certification",
+		Title:           "Java SE Programmer",
+		Details:         model.AchievementDetails{CertificationName: "Java SE Programmer", IssuedBy: "Oracle", EventDate: "2025-09-20"},
+	}), "student-1")
+
+	// Drafts are left off the type and level charts
+	create("student-1", competition("Lomba Draft", "national", "2025-09-25"))
+
+	// Months without achievements are filled in
+	trends, err := svc.GetTrends(ctx, nil, "month", "type")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trends.Points) != 7 || trends.Points[0].Period != "2025-09" || trends.Points[6].Period != "2026-03" {
+		t.Fatalf("expected monthly buckets from 2025-09 to 2026-03, got %+v", trends.Points)
+	}
+	if september := trends.Points[0]; september.Total != 2 || september.Counts["competition"] != 1 || september.Counts["certification"] != 1 {
+		t.Errorf("expected a competition and a certification in 2025-09, got %+v", september)
+	}
+	if october := trends.Points[1]; october.Total != 0 {
+		t.Errorf("expected an empty 2025-10 bucket, got %+v", october)
+	}
+	if len(trends.Keys) != 2 || trends.Keys[0] != "certification" || trends.Keys[1] != "competition" {
+		t.Errorf("expected certification and competition series, got %v", trends.Keys)
+	}
+
+	trends, err = svc.GetTrends(ctx, nil, "semester", "level")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trends.Points) != 2 || trends.Points[0].Period != "2025-GANJIL" || trends.Points[1].Period != "2025-GENAP" {
+		t.Fatalf("expected the 2025-GANJIL and 2025-GENAP semesters, got %+v", trends.Points)
+	}
+	if ganjil := trends.Points[0]; ganjil.Total != 2 || ganjil.Counts["national"] != 1 || ganjil.Counts["regional"] != 1 {
+		t.Errorf("expected only the two submitted competitions in 2025-GANJIL, got %+v", ganjil)
+	}
+
+	// Scoped to one student, by status
+	trends, err = svc.GetTrends(ctx, []string{"student-1"}, "year", "status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trends.Points) != 1 || trends.Points[0].Period != "2025/2026" {
+		t.Fatalf("expected a single academic year, got %+v", trends.Points)
+	}
+	if year := trends.Points[0]; year.Total != 4 || year.Counts[service.StatusDraft] != 1 || year.Counts[service.StatusSubmitted] != 3 {
+		t.Errorf("expected 1 draft and 3 submissions for student-1, got %+v", year)
+	}
+
+	// An empty scope has nothing to chart
+	trends, err = svc.GetTrends(ctx, []string{}, "month", "type")
+	if err != nil || len(trends.Points) != 0 {
+		t.Errorf("expected no trend points for an empty scope, got %+v %v", trends, err)
+	}
+
+	if _, err := svc.GetTrends(ctx, nil, "week", "mood"); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected ErrValidation for unknown granularity and groupBy, got %v", err)
+	}
+}
+
+// Test Semester Trends Follow the Configured Academic Periods
+func TestAchievementService_GetTrendsConfiguredPeriods(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	// An odd semester running into March and a short semester after the even one
+	if _, err := svc.SaveAcademicPeriod("2025-GANJIL", model.SaveAcademicPeriodRequest{StartDate: "2025-08-01", EndDate: "2026-03-20"}); err != nil {
+		t.Fatalf("failed to save period: %v", err)
+	}
+	if _, err := svc.SaveAcademicPeriod("2025-PENDEK", model.SaveAcademicPeriodRequest{StartDate: "2026-07-01", EndDate: "2026-08-15"}); err != nil {
+		t.Fatalf("failed to save period: %v", err)
+	}
+
+	for i, date := range []string{"2025-09-10", "2026-03-15", "2026-04-01", "2026-07-20"} {
+		_, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "competition",
+			Title:           date,
+			Details:         model.AchievementDetails{CompetitionName: date, CompetitionLevel: "national", EventDate: date},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement %d: %v", i, err)
+		}
+	}
+
+	trends, err := svc.GetTrends(ctx, nil, "semester", "status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []struct {
+		period string
+		total  int
+	}{{"2025-GANJIL", 2}, {"2025-GENAP", 1}, {"2025-PENDEK", 1}}
+	if len(trends.Points) != len(expected) {
+		t.Fatalf("expected %d semesters, got %+v", len(expected), trends.Points)
+	}
+	for i, e := range expected {
+		if trends.Points[i].Period != e.period || trends.Points[i].Total != e.total {
+			t.Errorf("expected %s with %d achievements at %d, got %+v", e.period, e.total, i, trends.Points[i])
+		}
+	}
+}
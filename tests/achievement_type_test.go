@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/points"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+)
+
+// Test Admin-Defined Achievement Types
+func TestAchievementService_CustomAchievementType(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	types, err := svc.GetAchievementTypes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(types) != 6 {
+		t.Fatalf("expected 6 built-in types, got %d", len(types))
+	}
+
+	_, err = svc.SaveAchievementType(model.AchievementTypeDefinition{
+		Name:               "community-service",
+		CustomFieldsSchema: map[string]interface{}{"type": "object", "format": "email"},
+	})
+	if !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected unsupported schema to be rejected, got %v", err)
+	}
+
+	_, err = svc.SaveAchievementType(model.AchievementTypeDefinition{
+		Name:          "community-service",
+		DefaultPoints: 15,
+		IsActive:      true,
+		CustomFieldsSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"hours"},
+			"properties": map[string]interface{}{
+				"hours":        map[string]interface{}{"type": "integer", "minimum": float64(1)},
+				"organization": map[string]interface{}{"type": "string"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to save achievement type: %v", err)
+	}
+
+	_, err = svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "community-service",
+		Title:           "Village Teaching",
+		Details:         model.AchievementDetails{CustomFields: map[string]interface{}{"hours": "many"}},
+	})
+	var validationErr *service.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "details.customFields.hours" {
+		t.Fatalf("expected details.customFields.hours error, got %v", err)
+	}
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "community-service",
+		Title:           "Village Teaching",
+		Details:         model.AchievementDetails{CustomFields: map[string]interface{}{"hours": float64(40)}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if created.Points != 15 {
+		t.Errorf("expected type default of 15 points, got %d", created.Points)
+	}
+
+	// Rescoring under a new rule version keeps the type default
+	if _, err := svc.SavePointsRuleSet(points.DefaultRuleSet().Rules, nil, "", "admin-1"); err != nil {
+		t.Fatalf("failed to save points rules: %v", err)
+	}
+	if _, err := svc.RecomputeAllPoints(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rescored, err := svc.GetAchievementByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rescored.Points != 15 || rescored.PointsRuleID != "type-default:community-service" {
+		t.Errorf("expected type default of 15 points after rescoring, got %d (rule %s)", rescored.Points, rescored.PointsRuleID)
+	}
+
+	stats, err := svc.GetStatistics(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count, ok := stats.ByType["certification"]; !ok || count != 0 {
+		t.Errorf("expected managed types to be listed with zero counts, got %v", stats.ByType)
+	}
+
+	if err := svc.DeactivateAchievementType("community-service"); err != nil {
+		t.Fatalf("failed to deactivate achievement type: %v", err)
+	}
+	_, err = svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "community-service",
+		Title:           "Beach Cleanup",
+	})
+	if !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected deactivated type to be rejected, got %v", err)
+	}
+}
+
+// Test Updating an Achievement Type Keeps the Fields Left Out of the Request
+func TestAchievementService_UpdateAchievementType(t *testing.T) {
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	_, err := svc.SaveAchievementType(model.AchievementTypeDefinition{
+		Name:           "community-service",
+		Description:    "Volunteering",
+		RequiredFields: []string{"organizationName"},
+		DefaultPoints:  15,
+		IsActive:       true,
+	})
+	if err != nil {
+		t.Fatalf("failed to save achievement type: %v", err)
+	}
+
+	points := 20
+	updated, err := svc.UpdateAchievementType("community-service", model.UpdateAchievementTypeRequest{DefaultPoints: &points})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !updated.IsActive || updated.DefaultPoints != 20 || updated.Description != "Volunteering" || len(updated.RequiredFields) != 1 {
+		t.Errorf("expected only the default points to change, got %+v", updated)
+	}
+
+	inactive := false
+	if updated, err = svc.UpdateAchievementType("community-service", model.UpdateAchievementTypeRequest{IsActive: &inactive}); err != nil || updated.IsActive {
+		t.Errorf("expected an explicit isActive to be applied, got %+v (%v)", updated, err)
+	}
+
+	// A schema survives updates that leave it out and is removed by an explicit null
+	update := func(body string) *model.AchievementTypeDefinition {
+		var req model.UpdateAchievementTypeRequest
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			t.Fatalf("failed to decode %s: %v", body, err)
+		}
+		updated, err := svc.UpdateAchievementType("community-service", req)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", body, err)
+		}
+		return updated
+	}
+	if updated := update(`{"customFieldsSchema": {"hours": {"type": "number"}}}`); updated.CustomFieldsSchema["hours"] == nil {
+		t.Errorf("expected the schema to be set, got %+v", updated.CustomFieldsSchema)
+	}
+	if updated := update(`{"defaultPoints": 25}`); updated.CustomFieldsSchema["hours"] == nil {
+		t.Errorf("expected an omitted schema to be kept, got %+v", updated.CustomFieldsSchema)
+	}
+	if updated := update(`{"customFieldsSchema": null}`); updated.CustomFieldsSchema != nil || updated.DefaultPoints != 25 {
+		t.Errorf("expected a null schema to remove it, got %+v", updated)
+	}
+
+	if _, err := svc.UpdateAchievementType("unknown-type", model.UpdateAchievementTypeRequest{}); !errors.Is(err, service.ErrAchievementNotFound) {
+		t.Errorf("expected ErrAchievementNotFound for an unknown type, got %v", err)
+	}
+}
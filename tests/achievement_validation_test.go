@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+	"time"
+)
+
+// Test Per-Type Validation of Achievement Details
+func TestAchievementService_ValidateDetails(t *testing.T) {
+	ctx := context.Background()
+	past := time.Now().AddDate(-1, 0, 0)
+	future := time.Now().AddDate(1, 0, 0)
+
+	tests := []struct {
+		name       string
+		input      model.CreateAchievementRequest
+		wantFields []string
+	}{
+		{
+			name: "Complete competition",
+			input: model.CreateAchievementRequest{
+				AchievementType: "competition",
+				Title:           "Programming Contest",
+				Details:         model.AchievementDetails{CompetitionName: "ICPC", CompetitionLevel: "national", Rank: 2},
+			},
+		},
+		{
+			name: "Competition with publication fields and no level",
+			input: model.CreateAchievementRequest{
+				AchievementType: "competition",
+				Title:           "Programming Contest",
+				Details:         model.AchievementDetails{CompetitionName: "ICPC", ISSN: "0317-8471"},
+			},
+			wantFields: []string{"details.issn", "details.competitionLevel"},
+		},
+		{
+			name: "Competition with no details",
+			input: model.CreateAchievementRequest{
+				AchievementType: "competition",
+				Title:           "Programming Contest",
+			},
+			wantFields: []string{"details.competitionName", "details.competitionLevel"},
+		},
+		{
+			name: "Unknown competition level",
+			input: model.CreateAchievementRequest{
+				AchievementType: "competition",
+				Title:           "Programming Contest",
+				Details:         model.AchievementDetails{CompetitionName: "ICPC", CompetitionLevel: "galactic"},
+			},
+			wantFields: []string{"details.competitionLevel"},
+		},
+		{
+			name: "Publication with bad ISSN check digit",
+			input: model.CreateAchievementRequest{
+				AchievementType: "publication",
+				Title:           "Paper",
+				Details:         model.AchievementDetails{PublicationType: "journal", PublicationTitle: "AI", ISSN: "0317-8472"},
+			},
+			wantFields: []string{"details.issn"},
+		},
+		{
+			name: "Organization period ends before it starts",
+			input: model.CreateAchievementRequest{
+				AchievementType: "organization",
+				Title:           "Student Council",
+				Details: model.AchievementDetails{
+					OrganizationName: "BEM",
+					Position:         "Chair",
+					Period:           &model.PeriodDetail{Start: future, End: past},
+				},
+			},
+			wantFields: []string{"details.period"},
+		},
+		{
+			name: "Expired certification",
+			input: model.CreateAchievementRequest{
+				AchievementType: "certification",
+				Title:           "Cloud Practitioner",
+				Details:         model.AchievementDetails{CertificationName: "AWS CCP", IssuedBy: "AWS", ValidUntil: &past},
+			},
+			wantFields: []string{"details.validUntil"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewAchievementService(mocks.NewMockAchievementRepository())
+
+			_, err := svc.CreateAchievement(ctx, "student-1", tt.input)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *service.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			got := make(map[string]bool)
+			for _, f := range validationErr.Fields {
+				got[f.Field] = true
+			}
+			for _, field := range tt.wantFields {
+				if !got[field] {
+					t.Errorf("expected error on %s, got %+v", field, validationErr.Fields)
+				}
+			}
+		})
+	}
+}
+
+// Test Submitting Re-Checks Details Against the Current Type Rules
+func TestAchievementService_ValidateOnSubmit(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewAchievementService(mocks.NewMockAchievementRepository())
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Programming Contest",
+		Details:         model.AchievementDetails{CompetitionName: "ICPC", CompetitionLevel: "national"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	refID := created.ID.Hex()
+
+	definition := model.AchievementTypeDefinition{
+		Name:           "competition",
+		IsActive:       true,
+		RequiredFields: []string{"competitionName", "competitionLevel", "rank"},
+	}
+	if _, err := svc.SaveAchievementType(definition); err != nil {
+		t.Fatalf("failed to save achievement type: %v", err)
+	}
+
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); !errors.Is(err, service.ErrValidation) {
+		t.Fatalf("expected ErrValidation submitting without a rank, got %v", err)
+	}
+
+	if _, err := svc.UpdateAchievement(ctx, refID, "student-1", model.UpdateAchievementRequest{
+		Title:   "Programming Contest",
+		Details: model.AchievementDetails{CompetitionName: "ICPC", CompetitionLevel: "national", Rank: 2},
+	}); err != nil {
+		t.Fatalf("failed to update achievement: %v", err)
+	}
+	if _, err := svc.SubmitForVerification(ctx, refID, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+	note := "Wrong rank"
+	if _, err := svc.VerifyAchievement(ctx, refID, "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "reject", Note: &note}); err != nil {
+		t.Fatalf("failed to reject achievement: %v", err)
+	}
+
+	definition.RequiredFields = append(definition.RequiredFields, "medalType")
+	if _, err := svc.SaveAchievementType(definition); err != nil {
+		t.Fatalf("failed to save achievement type: %v", err)
+	}
+	if _, err := svc.ResubmitAchievement(ctx, refID, "student-1"); !errors.Is(err, service.ErrValidation) {
+		t.Errorf("expected ErrValidation resubmitting without a medal type, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+	"time"
+)
+
+// Test Expired Certifications Stop Counting Towards Points
+func TestAchievementService_CertificationExpiry(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	create := func(studentID, title string, validFor time.Duration) string {
+		validUntil := time.Now().Add(validFor)
+		created, err := svc.CreateAchievement(ctx, studentID, model.CreateAchievementRequest{
+			AchievementType: "certification",
+			Title:           title,
+			Details:         model.AchievementDetails{CertificationName: title, IssuedBy: "Oracle", ValidUntil: &validUntil},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		return created.ID.Hex()
+	}
+
+	soon := create("student-1", "Java SE Programmer", 10*24*time.Hour)
+	create("student-2", "Oracle Database Associate", 60*24*time.Hour)
+
+	expiring, err := svc.GetExpiringAchievements(ctx, nil, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].ID.Hex() != soon || expiring[0].DaysLeft != 9 {
+		t.Fatalf("expected only the certification expiring in 10 days, got %+v", expiring)
+	}
+
+	expiring, err = svc.GetExpiringAchievements(ctx, []string{"student-1"}, 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].StudentID != "student-1" {
+		t.Errorf("expected the list scoped to student-1, got %d achievements", len(expiring))
+	}
+
+	if _, err := svc.SubmitForVerification(ctx, soon, "student-1"); err != nil {
+		t.Fatalf("failed to submit achievement: %v", err)
+	}
+	if _, err := svc.VerifyAchievement(ctx, soon, "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+		t.Fatalf("failed to verify achievement: %v", err)
+	}
+
+	// Other types with a validity date never expire
+	if _, err := svc.SaveAchievementType(model.AchievementTypeDefinition{
+		Name:           "license",
+		IsActive:       true,
+		RequiredFields: []string{"validUntil"},
+	}); err != nil {
+		t.Fatalf("failed to save achievement type: %v", err)
+	}
+	licenseValidUntil := time.Now().Add(5 * 24 * time.Hour)
+	if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "license",
+		Title:           "Driving License",
+		Details:         model.AchievementDetails{ValidUntil: &licenseValidUntil},
+	}); err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if expiring, _ := svc.GetExpiringAchievements(ctx, []string{"student-1"}, 30*24*time.Hour); len(expiring) != 1 || expiring[0].ID.Hex() != soon {
+		t.Errorf("expected only the certification to be expiring, got %+v", expiring)
+	}
+
+	marked, err := svc.MarkExpiredCertifications(ctx, time.Now().Add(11*24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if marked != 1 {
+		t.Errorf("expected 1 certification marked expired, got %d", marked)
+	}
+	if marked, _ := svc.MarkExpiredCertifications(ctx, time.Now().Add(11*24*time.Hour)); marked != 0 {
+		t.Errorf("expected an expired certification to be marked once, got %d", marked)
+	}
+
+	// By default expired certifications are excluded
+	report, err := svc.GetStudentReport(ctx, "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalPoints != 0 || report.ExpiredPoints != 25 || report.Achievements[0].ExpiredAt == nil {
+		t.Errorf("expected the expired certification to be excluded, got total=%d %+v", report.TotalPoints, report.PointsBreakdown)
+	}
+
+	// Down-weighted to half
+	svc.SetExpiredCertificationWeight(50)
+	report, err = svc.GetStudentReport(ctx, "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalPoints != 12 || report.VerifiedPoints != 12 {
+		t.Errorf("expected 12 of 25 points at half weight, got total=%d %+v", report.TotalPoints, report.PointsBreakdown)
+	}
+
+	stats, err := svc.GetStatistics(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalPoints != 12 || stats.ExpiredPoints != 25 || stats.ExpiredWeight != 50 {
+		t.Errorf("expected 12 points with 25 expired at weight 50, got total=%d weight=%d %+v", stats.TotalPoints, stats.ExpiredWeight, stats.PointsBreakdown)
+	}
+	if len(stats.TopStudents) != 1 || stats.TopStudents[0].TotalPoints != 12 {
+		t.Errorf("expected student-1 ranked with 12 points, got %+v", stats.TopStudents)
+	}
+}
+
+// Test Parsing the Expiring Window
+func TestParseExpiringWithin(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"", 30 * 24 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"72h", 72 * time.Hour, false},
+		{"0d", 0, true},
+		{"400d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		within, err := service.ParseExpiringWithin(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error=%v, got %v", tt.value, tt.wantErr, err)
+			continue
+		}
+		if within != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.value, tt.expected, within)
+		}
+	}
+}
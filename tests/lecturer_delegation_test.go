@@ -0,0 +1,225 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var advisorStage = model.ApprovalStage{Name: "advisor", Permission: "achievement:verify", AdvisorOnly: true}
+
+func newDelegationRepos() (*mocks.MockStudentRepository, *mocks.MockLecturerRepository) {
+	studentRepo := mocks.NewMockStudentRepository()
+	studentRepo.AddStudent("student-1", "student-user", "lecturer-1")
+
+	lecturerRepo := mocks.NewMockLecturerRepository()
+	lecturerRepo.AddLecturer("advisor-user", "lecturer-1")
+	lecturerRepo.AddLecturer("delegate-user", "lecturer-2")
+	lecturerRepo.AddLecturer("other-user", "lecturer-3")
+	return studentRepo, lecturerRepo
+}
+
+// Test Delegated Verification Access Follows the Delegation's Date Range
+func TestAdvisorAuthorizer_Delegation(t *testing.T) {
+	studentRepo, lecturerRepo := newDelegationRepos()
+	auth := service.NewAdvisorAuthorizer(studentRepo, lecturerRepo, []string{"achievement:verify"})
+
+	if err := auth.AuthorizeStage(advisorStage, "advisor-user", "student-1"); err != nil {
+		t.Fatalf("expected the advisor to be authorized, got %v", err)
+	}
+	if err := auth.AuthorizeStage(advisorStage, "delegate-user", "student-1"); !errors.Is(err, service.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized without a delegation, got %v", err)
+	}
+
+	studentRepo.AddStudent("student-9", "", "lecturer-3")
+	today := time.Now()
+	day := func(offset int) time.Time {
+		return time.Date(today.Year(), today.Month(), today.Day()+offset, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		start, end int
+		authorized bool
+	}{
+		{name: "ended yesterday", start: -5, end: -1, authorized: false},
+		{name: "starts tomorrow", start: 1, end: 5, authorized: false},
+		{name: "starts today", start: 0, end: 5, authorized: true},
+		{name: "ends today", start: -5, end: 0, authorized: true},
+		{name: "single day", start: 0, end: 0, authorized: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delegation := &model.LecturerDelegation{
+				DelegatorID: "lecturer-1",
+				DelegateID:  "lecturer-2",
+				StartDate:   day(tt.start),
+				EndDate:     day(tt.end),
+				CreatedBy:   "advisor-user",
+			}
+			if err := lecturerRepo.CreateDelegation(delegation); err != nil {
+				t.Fatalf("failed to create delegation: %v", err)
+			}
+			defer lecturerRepo.DeleteDelegation("lecturer-1", delegation.ID)
+
+			err := auth.AuthorizeStage(advisorStage, "delegate-user", "student-1")
+			if tt.authorized && err != nil {
+				t.Errorf("expected the delegate to be authorized, got %v", err)
+			}
+			if !tt.authorized && !errors.Is(err, service.ErrUnauthorized) {
+				t.Errorf("expected ErrUnauthorized, got %v", err)
+			}
+
+			// A delegation never reaches students of other advisors
+			if err := auth.AuthorizeStage(advisorStage, "delegate-user", "student-9"); !errors.Is(err, service.ErrUnauthorized) {
+				t.Errorf("expected ErrUnauthorized for another advisor's student, got %v", err)
+			}
+		})
+	}
+}
+
+// Test a Delegate Verifies Through the Achievement Service Until the Delegation Is Revoked
+func TestAchievementService_DelegatedVerification(t *testing.T) {
+	ctx := context.Background()
+	studentRepo, lecturerRepo := newDelegationRepos()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+	svc.SetVerificationAuthorizer(service.NewAdvisorAuthorizer(studentRepo, lecturerRepo, []string{"achievement:verify"}))
+
+	submit := func(title string) string {
+		created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "competition",
+			Title:           title,
+			Details:         model.AchievementDetails{CompetitionName: title, CompetitionLevel: "national"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		if _, err := svc.SubmitForVerification(ctx, created.ID.Hex(), "student-1"); err != nil {
+			t.Fatalf("failed to submit achievement: %v", err)
+		}
+		return created.ID.Hex()
+	}
+
+	now := time.Now()
+	delegation := &model.LecturerDelegation{
+		DelegatorID: "lecturer-1",
+		DelegateID:  "lecturer-2",
+		StartDate:   now.AddDate(0, 0, -1),
+		EndDate:     now.AddDate(0, 0, 1),
+		CreatedBy:   "advisor-user",
+	}
+	if err := lecturerRepo.CreateDelegation(delegation); err != nil {
+		t.Fatalf("failed to create delegation: %v", err)
+	}
+
+	first := submit("Hackathon")
+	result, err := svc.VerifyAchievement(ctx, first, "delegate-user", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"})
+	if err != nil {
+		t.Fatalf("expected the delegate to verify, got %v", err)
+	}
+	if result.VerifiedBy == nil || *result.VerifiedBy != "delegate-user" {
+		t.Errorf("expected the verification to be credited to the delegate, got %v", result.VerifiedBy)
+	}
+
+	if err := lecturerRepo.DeleteDelegation("lecturer-1", delegation.ID); err != nil {
+		t.Fatalf("failed to revoke delegation: %v", err)
+	}
+	second := submit("Programming Contest")
+	if _, err := svc.VerifyAchievement(ctx, second, "delegate-user", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized after revocation, got %v", err)
+	}
+	if _, err := svc.VerifyAchievement(ctx, second, "advisor-user", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+		t.Errorf("expected the advisor to keep access, got %v", err)
+	}
+}
+
+// Test Delegation Endpoints
+func TestLecturerService_DelegationHandlers(t *testing.T) {
+	os.Setenv("API_KEY", "test-key")
+	studentRepo, lecturerRepo := newDelegationRepos()
+	lecturerService := service.NewLecturerService(lecturerRepo)
+
+	// Stands in for AuthRequired: the caller comes from test headers
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Get("X-User"))
+		c.Locals("role", c.Get("X-Role"))
+		return c.Next()
+	})
+	app.Get("/:key/v1/lecturers/:id/delegations", lecturerService.GetDelegationsService)
+	app.Post("/:key/v1/lecturers/:id/delegations", lecturerService.CreateDelegationService)
+	app.Delete("/:key/v1/lecturers/:id/delegations/:delegationId", lecturerService.DeleteDelegationService)
+
+	call := func(method, path, userID, role, body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, "/test-key/v1/lecturers"+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", userID)
+		req.Header.Set("X-Role", role)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		raw, _ := io.ReadAll(resp.Body)
+		payload := map[string]interface{}{}
+		json.Unmarshal(raw, &payload)
+		return resp.StatusCode, payload
+	}
+
+	today := time.Now().Format("2006-01-02")
+	nextWeek := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	valid := `{"delegate_id":"lecturer-2","start_date":"` + today + `","end_date":"` + nextWeek + `"}`
+
+	if status, _ := call(http.MethodPost, "/lecturer-1/delegations", "other-user", service.RoleLecturer, valid); status != fiber.StatusForbidden {
+		t.Errorf("expected 403 for another lecturer, got %d", status)
+	}
+	if status, _ := call(http.MethodPost, "/lecturer-1/delegations", "advisor-user", service.RoleLecturer,
+		`{"delegate_id":"lecturer-2","start_date":"`+nextWeek+`","end_date":"`+today+`"}`); status != fiber.StatusBadRequest {
+		t.Errorf("expected 400 for an end date before the start date, got %d", status)
+	}
+	if status, _ := call(http.MethodPost, "/lecturer-1/delegations", "advisor-user", service.RoleLecturer,
+		`{"delegate_id":"lecturer-1","start_date":"`+today+`","end_date":"`+nextWeek+`"}`); status != fiber.StatusBadRequest {
+		t.Errorf("expected 400 for a self-delegation, got %d", status)
+	}
+
+	status, created := call(http.MethodPost, "/lecturer-1/delegations", "advisor-user", service.RoleLecturer, valid)
+	if status != fiber.StatusCreated {
+		t.Fatalf("expected 201, got %d (%v)", status, created)
+	}
+	delegationID, _ := created["data"].(map[string]interface{})["id"].(string)
+
+	status, listed := call(http.MethodGet, "/lecturer-1/delegations", "admin-user", service.RoleAdmin, "")
+	if data, _ := listed["data"].([]interface{}); status != fiber.StatusOK || len(data) != 1 {
+		t.Errorf("expected the admin to list 1 delegation, got %d (%v)", status, listed)
+	}
+
+	auth := service.NewAdvisorAuthorizer(studentRepo, lecturerRepo, []string{"achievement:verify"})
+	if err := auth.AuthorizeStage(advisorStage, "delegate-user", "student-1"); err != nil {
+		t.Errorf("expected the delegate to be authorized, got %v", err)
+	}
+
+	if status, _ := call(http.MethodDelete, "/lecturer-1/delegations/unknown", "advisor-user", service.RoleLecturer, ""); status != fiber.StatusNotFound {
+		t.Errorf("expected 404 for an unknown delegation, got %d", status)
+	}
+	if status, _ := call(http.MethodDelete, "/lecturer-1/delegations/"+delegationID, "advisor-user", service.RoleLecturer, ""); status != fiber.StatusOK {
+		t.Errorf("expected 200 revoking the delegation, got %d", status)
+	}
+	if err := auth.AuthorizeStage(advisorStage, "delegate-user", "student-1"); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized after revocation, got %v", err)
+	}
+}
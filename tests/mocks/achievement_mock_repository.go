@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"student-report/app/model"
 	"student-report/app/repository"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -20,6 +23,19 @@ type MockAchievementRepository struct {
 	achievementReferences  map[string]*model.AchievementReference // refID -> Reference
 	mongoIDToRefID         map[string]string                 // mongoID -> refID
 	studentAchievements    map[string][]string               // studentID -> []refID
+	statusHistory          map[string][]model.AchievementStatusHistory // refID -> history
+	revisions              map[string][]model.AchievementRevision      // mongoID -> revisions
+	approvalChains         map[string]model.ApprovalChain              // type|level -> chain
+	comments               []*model.AchievementComment
+	pointsRuleSets         []model.PointsRuleSet
+	achievementTypes       map[string]model.AchievementTypeDefinition
+	missingStudents        map[string]bool
+	approvalStagesErr      error
+	approvalStagesOK       int
+	commentErr             error
+	referenceErrs          map[string]error
+	academicPeriods        map[string]model.AcademicPeriod
+	transcriptDocuments    []model.TranscriptDocument
 	nextRefID              int
 }
 
@@ -29,6 +45,9 @@ func NewMockAchievementRepository() *MockAchievementRepository {
 		achievementReferences: make(map[string]*model.AchievementReference),
 		mongoIDToRefID:        make(map[string]string),
 		studentAchievements:   make(map[string][]string),
+		statusHistory:         make(map[string][]model.AchievementStatusHistory),
+		revisions:             make(map[string][]model.AchievementRevision),
+		approvalChains:        make(map[string]model.ApprovalChain),
 		nextRefID:             1,
 	}
 }
@@ -75,11 +94,45 @@ func (m *MockAchievementRepository) UpdateAchievementMongo(ctx context.Context,
 	achievement.Details = update.Details
 	achievement.Tags = update.Tags
 	achievement.Points = update.Points
+	achievement.PointsRuleID = update.PointsRuleID
+	achievement.PointsVersion = update.PointsVersion
+	achievement.NormalizedTitle = update.NormalizedTitle
+	if update.IsTeam() {
+		achievement.TeamMembers = update.TeamMembers
+	}
+	achievement.ExpiredAt = nil
 	achievement.UpdatedAt = time.Now()
 
 	return nil
 }
 
+func (m *MockAchievementRepository) UpdateAchievementPointsMongo(ctx context.Context, mongoID string, points int, ruleID string, version int, teamMembers []model.TeamMember) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	achievement, exists := m.mongoAchievements[mongoID]
+	if !exists {
+		return errors.New("achievement not found")
+	}
+
+	achievement.Points = points
+	achievement.PointsRuleID = ruleID
+	achievement.PointsVersion = version
+	if len(teamMembers) > 0 {
+		achievement.TeamMembers = teamMembers
+	}
+
+	return nil
+}
+
+func (m *MockAchievementRepository) DeleteAchievementMongo(ctx context.Context, mongoID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.mongoAchievements, mongoID)
+	return nil
+}
+
 func (m *MockAchievementRepository) SoftDeleteAchievementMongo(ctx context.Context, mongoID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -104,18 +157,205 @@ func (m *MockAchievementRepository) AddAttachmentMongo(ctx context.Context, mong
 		return errors.New("achievement not found")
 	}
 
-	attachment.UploadedAt = time.Now()
 	achievement.Attachments = append(achievement.Attachments, attachment)
 	achievement.UpdatedAt = time.Now()
 
 	return nil
 }
 
+func (m *MockAchievementRepository) FindDuplicateCandidatesMongo(ctx context.Context, achievement *model.Achievement) ([]model.Achievement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	candidates := []model.Achievement{}
+	for _, other := range m.mongoAchievements {
+		if other.IsDeleted || other.ID == achievement.ID {
+			continue
+		}
+		d, o := achievement.Details, other.Details
+		if (achievement.NormalizedTitle != "" && other.NormalizedTitle == achievement.NormalizedTitle) ||
+			(other.NormalizedTitle == "" && achievement.Title != "" && looseText(other.Title) == looseText(achievement.Title)) ||
+			(d.CompetitionName != "" && d.EventDate != "" && o.EventDate == d.EventDate &&
+				looseText(o.CompetitionName) == looseText(d.CompetitionName)) ||
+			(d.CertificationNumber != "" && o.CertificationNumber == d.CertificationNumber) ||
+			(d.ISSN != "" && o.ISSN == d.ISSN) {
+			candidates = append(candidates, *other)
+		}
+	}
+
+	return candidates, nil
+}
+
+// looseText mirrors the repository's case- and punctuation-insensitive competition name match
+func looseText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func (m *MockAchievementRepository) SetPossibleDuplicatesMongo(ctx context.Context, mongoID string, matches []model.DuplicateMatch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	achievement, exists := m.mongoAchievements[mongoID]
+	if !exists {
+		return errors.New("achievement not found")
+	}
+	achievement.Duplicates = matches
+
+	return nil
+}
+
+func (m *MockAchievementRepository) MarkExpiredAchievementsMongo(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var marked int64
+	for _, achievement := range m.mongoAchievements {
+		validUntil := achievement.Details.ValidUntil
+		if achievement.AchievementType != "certification" || achievement.IsDeleted || achievement.ExpiredAt != nil ||
+			validUntil == nil || validUntil.After(now) {
+			continue
+		}
+		expiredAt := now
+		achievement.ExpiredAt = &expiredAt
+		marked++
+	}
+
+	return marked, nil
+}
+
+func (m *MockAchievementRepository) GetExpiringAchievementsMongo(ctx context.Context, studentIDs []string, from, until time.Time) ([]model.Achievement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var results []model.Achievement
+	for _, achievement := range m.mongoAchievements {
+		validUntil := achievement.Details.ValidUntil
+		if achievement.AchievementType != "certification" || achievement.IsDeleted || achievement.ExpiredAt != nil ||
+			validUntil == nil || !validUntil.After(from) || validUntil.After(until) {
+			continue
+		}
+		if len(studentIDs) > 0 && !involvesStudent(achievement, studentIDs) {
+			continue
+		}
+		results = append(results, *achievement)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Details.ValidUntil.Before(*results[j].Details.ValidUntil)
+	})
+
+	return results, nil
+}
+
+func (m *MockAchievementRepository) ReplaceAttachmentMongo(ctx context.Context, mongoID string, attachment model.Attachment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	achievement, exists := m.mongoAchievements[mongoID]
+	if !exists || achievement.IsDeleted {
+		return errors.New("achievement not found")
+	}
+
+	for i := range achievement.Attachments {
+		if achievement.Attachments[i].ID == attachment.ID {
+			achievement.Attachments[i] = attachment
+			achievement.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	return errors.New("attachment not found")
+}
+
+func (m *MockAchievementRepository) RemoveAttachmentMongo(ctx context.Context, mongoID, attachmentID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	achievement, exists := m.mongoAchievements[mongoID]
+	if !exists || achievement.IsDeleted {
+		return errors.New("achievement not found")
+	}
+
+	for i := range achievement.Attachments {
+		if achievement.Attachments[i].ID == attachmentID {
+			achievement.Attachments = append(achievement.Attachments[:i:i], achievement.Attachments[i+1:]...)
+			achievement.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	return errors.New("attachment not found")
+}
+
+func (m *MockAchievementRepository) AssignAttachmentIDsMongo(ctx context.Context, mongoID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	achievement, exists := m.mongoAchievements[mongoID]
+	if !exists {
+		return errors.New("achievement not found")
+	}
+
+	for i := range achievement.Attachments {
+		if achievement.Attachments[i].ID == "" {
+			achievement.Attachments[i].ID = primitive.NewObjectID().Hex()
+		}
+	}
+
+	return nil
+}
+
+func (m *MockAchievementRepository) CreateAchievementRevision(ctx context.Context, revision *model.AchievementRevision) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revision.ID = primitive.NewObjectID()
+	revision.Revision = len(m.revisions[revision.AchievementID]) + 1
+	revision.CreatedAt = time.Now()
+	m.revisions[revision.AchievementID] = append(m.revisions[revision.AchievementID], *revision)
+
+	return nil
+}
+
+func (m *MockAchievementRepository) GetAchievementRevisions(ctx context.Context, mongoID string) ([]model.AchievementRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]model.AchievementRevision{}, m.revisions[mongoID]...), nil
+}
+
 // PostgreSQL Operations
-func (m *MockAchievementRepository) CreateAchievementReference(studentID, mongoID string) (string, error) {
+func (m *MockAchievementRepository) CreateAchievementReferences(mongoID string, studentIDs []string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// All or none, as in the repository's transaction
+	for _, studentID := range studentIDs {
+		if err, fails := m.referenceErrs[studentID]; fails {
+			return nil, err
+		}
+	}
+
+	refIDs := make([]string, len(studentIDs))
+	for i, studentID := range studentIDs {
+		refIDs[i] = m.createReference(studentID, mongoID)
+	}
+	return refIDs, nil
+}
+
+// FailReferences makes creating a reference for the student fail with err
+func (m *MockAchievementRepository) FailReferences(studentID string, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.referenceErrs == nil {
+		m.referenceErrs = make(map[string]error)
+	}
+	m.referenceErrs[studentID] = err
+}
+
+func (m *MockAchievementRepository) createReference(studentID, mongoID string) string {
 	refID := fmt.Sprintf("ref-%d", m.nextRefID)
 	m.nextRefID++
 
@@ -129,10 +369,13 @@ func (m *MockAchievementRepository) CreateAchievementReference(studentID, mongoI
 	}
 
 	m.achievementReferences[refID] = ref
-	m.mongoIDToRefID[mongoID] = refID
+	// Team members reference the same document; the creator's reference stays the primary one
+	if _, exists := m.mongoIDToRefID[mongoID]; !exists {
+		m.mongoIDToRefID[mongoID] = refID
+	}
 	m.studentAchievements[studentID] = append(m.studentAchievements[studentID], refID)
 
-	return refID, nil
+	return refID
 }
 
 func (m *MockAchievementRepository) GetAchievementReference(id string) (*model.AchievementReference, error) {
@@ -144,7 +387,9 @@ func (m *MockAchievementRepository) GetAchievementReference(id string) (*model.A
 		return nil, errors.New("achievement reference not found")
 	}
 
-	return ref, nil
+	// A copy, like a row read from the database; changes go through the repository
+	copied := *ref
+	return &copied, nil
 }
 
 func (m *MockAchievementRepository) GetAchievementReferenceByMongoID(mongoID string) (*model.AchievementReference, error) {
@@ -156,59 +401,71 @@ func (m *MockAchievementRepository) GetAchievementReferenceByMongoID(mongoID str
 		return nil, errors.New("achievement reference not found")
 	}
 
-	return m.achievementReferences[refID], nil
+	copied := *m.achievementReferences[refID]
+	return &copied, nil
 }
 
-func (m *MockAchievementRepository) UpdateAchievementStatus(id, status string, submittedAt *time.Time) error {
+func (m *MockAchievementRepository) GetAchievementReferencesByMongoID(mongoID string) ([]model.AchievementReference, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	ref, exists := m.achievementReferences[id]
-	if !exists {
-		return errors.New("achievement reference not found")
+	var refs []model.AchievementReference
+	for _, ref := range m.achievementReferences {
+		if ref.MongoAchievementID == mongoID {
+			refs = append(refs, *ref)
+		}
 	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refIndex(refs[i].ID) < refIndex(refs[j].ID)
+	})
 
-	ref.Status = status
-	if submittedAt != nil {
-		ref.SubmittedAt = submittedAt
-	}
-	ref.UpdatedAt = time.Now()
+	return refs, nil
+}
 
-	return nil
+// refIndex orders references by creation, which time.Now() cannot do reliably
+func refIndex(refID string) int {
+	var index int
+	fmt.Sscanf(refID, "ref-%d", &index)
+	return index
 }
 
-func (m *MockAchievementRepository) VerifyAchievement(id, verifiedBy string) error {
+func (m *MockAchievementRepository) TransitionAchievement(change *model.AchievementTransition) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	ref, exists := m.achievementReferences[id]
+	history := change.History
+	ref, exists := m.achievementReferences[history.AchievementReferenceID]
 	if !exists {
-		return errors.New("achievement reference not found")
+		return false, errors.New("achievement reference not found")
+	}
+	if ref.Status != history.FromStatus {
+		return false, nil
+	}
+	if change.Comment != nil && m.commentErr != nil {
+		return false, m.commentErr
 	}
 
 	now := time.Now()
-	ref.Status = "verified"
-	ref.VerifiedAt = &now
-	ref.VerifiedBy = &verifiedBy
-	ref.UpdatedAt = time.Now()
-
-	return nil
-}
+	ref.Status = history.ToStatus
+	ref.SubmittedAt = change.SubmittedAt
+	ref.RejectionNote = change.RejectionNote
+	if change.VerifiedBy != nil {
+		verifiedBy := *change.VerifiedBy
+		ref.VerifiedAt = &now
+		ref.VerifiedBy = &verifiedBy
+	}
+	ref.UpdatedAt = now
 
-func (m *MockAchievementRepository) RejectAchievement(id, note string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	history.ID = fmt.Sprintf("history-%d", len(m.statusHistory[history.AchievementReferenceID])+1)
+	history.CreatedAt = now
+	m.statusHistory[history.AchievementReferenceID] = append(m.statusHistory[history.AchievementReferenceID], history)
+	change.History = history
 
-	ref, exists := m.achievementReferences[id]
-	if !exists {
-		return errors.New("achievement reference not found")
+	if change.Comment != nil {
+		m.storeComment(change.Comment)
 	}
 
-	ref.Status = "rejected"
-	ref.RejectionNote = &note
-	ref.UpdatedAt = time.Now()
-
-	return nil
+	return true, nil
 }
 
 func (m *MockAchievementRepository) GetAchievementsByStudentID(studentID string) ([]model.AchievementReference, error) {
@@ -271,17 +528,68 @@ func (m *MockAchievementRepository) GetAchievementsWithFilter(ctx context.Contex
 			continue
 		}
 
-		if filter.StudentID != nil && achievement.StudentID != *filter.StudentID {
+		if filter.StudentID != nil && !involvesStudent(achievement, []string{*filter.StudentID}) {
+			continue
+		}
+
+		if filter.AcademicPeriod != nil && !filter.AcademicPeriod.Contains(achievement.ReportDate()) {
 			continue
 		}
 
 		results = append(results, *achievement)
 	}
 
-	return results, int64(len(results)), nil
+	// Sort and page like the repository, with the id as tiebreaker
+	sortField := "createdAt"
+	if filter.SortBy != nil && *filter.SortBy != "" {
+		sortField = *filter.SortBy
+	}
+	descending := filter.SortOrder == nil || *filter.SortOrder != "asc"
+	sort.Slice(results, func(i, j int) bool {
+		if c := compareSortField(&results[i], &results[j], sortField); c != 0 {
+			return (c < 0) != descending
+		}
+		return (results[i].ID.Hex() < results[j].ID.Hex()) != descending
+	})
+
+	total := int64(len(results))
+	page, limit := 1, 10
+	if filter.Page > 0 {
+		page = filter.Page
+	}
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	start := (page - 1) * limit
+	if start >= len(results) {
+		return []model.Achievement{}, total, nil
+	}
+	end := start + limit
+	if end > len(results) {
+		end = len(results)
+	}
+
+	return results[start:end], total, nil
+}
+
+// compareSortField orders two achievements on a sortBy field; unknown fields compare equal
+func compareSortField(a, b *model.Achievement, field string) int {
+	switch field {
+	case "createdAt":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updatedAt":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case "points":
+		return a.Points - b.Points
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	case "achievementType":
+		return strings.Compare(a.AchievementType, b.AchievementType)
+	}
+	return 0
 }
 
-func (m *MockAchievementRepository) GetAchievementStatistics(ctx context.Context, studentIDs []string) (*model.AchievementStatistics, error) {
+func (m *MockAchievementRepository) GetAchievementStatistics(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) (*model.AchievementStatistics, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -292,35 +600,153 @@ func (m *MockAchievementRepository) GetAchievementStatistics(ctx context.Context
 
 	// Count by type
 	for _, achievement := range m.mongoAchievements {
+		if period != nil && !period.Contains(achievement.ReportDate()) {
+			continue
+		}
 		if !achievement.IsDeleted {
 			stats.TotalAchievements++
 			stats.ByType[achievement.AchievementType]++
-			stats.TotalPoints += achievement.Points
 		}
 	}
 
-	// Count by status
+	// Count by status; points only count once verified
 	for _, ref := range m.achievementReferences {
+		if achievement, exists := m.mongoAchievements[ref.MongoAchievementID]; period != nil && (!exists || !period.Contains(achievement.ReportDate())) {
+			continue
+		}
 		stats.ByStatus[ref.Status]++
-		if ref.Status == "submitted" {
+		if achievement, exists := m.mongoAchievements[ref.MongoAchievementID]; exists && !achievement.IsDeleted {
+			stats.Add(ref.Status, achievement.PointsFor(ref.StudentID))
+			if achievement.ExpiredAt != nil {
+				stats.AddExpired(ref.Status, achievement.PointsFor(ref.StudentID))
+			}
+		}
+		if model.IsPendingReview(ref.Status) {
 			stats.PendingVerification++
 		}
 		if ref.Status == "verified" {
 			stats.RecentVerified++
 		}
 	}
+	stats.TotalPoints = stats.VerifiedPoints
 
 	return stats, nil
 }
 
-func (m *MockAchievementRepository) GetTopStudents(ctx context.Context, limit int) ([]model.StudentAchievementCount, error) {
-	return []model.StudentAchievementCount{}, nil
+func (m *MockAchievementRepository) GetStudentPoints(ctx context.Context, studentIDs []string, period *model.AcademicPeriod) ([]model.StudentAchievementCount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	byStudent := make(map[string]*model.StudentAchievementCount)
+	for _, ref := range m.achievementReferences {
+		if studentIDs != nil && !containsString(studentIDs, ref.StudentID) {
+			continue
+		}
+		achievement, exists := m.mongoAchievements[ref.MongoAchievementID]
+		if !exists || achievement.IsDeleted || ref.Status == "deleted" {
+			continue
+		}
+		if period != nil && !period.Contains(achievement.ReportDate()) {
+			continue
+		}
+		student, exists := byStudent[ref.StudentID]
+		if !exists {
+			student = &model.StudentAchievementCount{StudentID: ref.StudentID}
+			byStudent[ref.StudentID] = student
+		}
+		student.Add(ref.Status, achievement.PointsFor(ref.StudentID))
+		if achievement.ExpiredAt != nil {
+			student.AddExpired(ref.Status, achievement.PointsFor(ref.StudentID))
+		}
+		if ref.Status == "verified" {
+			student.Count++
+			verified := *achievement
+			verified.Points = achievement.PointsFor(ref.StudentID)
+			student.Verified = append(student.Verified, verified)
+		}
+		student.TotalPoints = student.VerifiedPoints
+	}
+
+	students := []model.StudentAchievementCount{}
+	for _, student := range byStudent {
+		students = append(students, *student)
+	}
+	return students, nil
+}
+
+func (m *MockAchievementRepository) GetDailyStatistics(ctx context.Context, studentIDs []string, groupBy string) ([]model.DailyStatistics, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if groupBy != "type" && groupBy != "level" && groupBy != "status" {
+		return nil, fmt.Errorf("unsupported trend group %q", groupBy)
+	}
+
+	type bucket struct{ day, key, status string }
+	counts := make(map[bucket]int)
+	for _, achievement := range m.mongoAchievements {
+		if achievement.IsDeleted || (len(studentIDs) > 0 && !involvesStudent(achievement, studentIDs)) {
+			continue
+		}
+		if groupBy == "level" && achievement.AchievementType != "competition" {
+			continue
+		}
+		day := achievement.ReportDate().Format("2006-01-02")
+		for _, ref := range m.achievementReferences {
+			if ref.MongoAchievementID != achievement.ID.Hex() || ref.Status == "deleted" {
+				continue
+			}
+			if len(studentIDs) > 0 && !containsString(studentIDs, ref.StudentID) {
+				continue
+			}
+			key := ref.Status
+			switch groupBy {
+			case "type":
+				key = achievement.AchievementType
+			case "level":
+				key = achievement.Details.CompetitionLevel
+			}
+			counts[bucket{day, key, ref.Status}]++
+		}
+	}
+
+	results := []model.DailyStatistics{}
+	for b, count := range counts {
+		results = append(results, model.DailyStatistics{Day: b.day, Key: b.key, Status: b.status, Count: count})
+	}
+	return results, nil
 }
 
 func (m *MockAchievementRepository) GetStudentInfo(studentID string) (string, string) {
 	return "Test Student", "123456"
 }
 
+func (m *MockAchievementRepository) StudentExists(studentID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return !m.missingStudents[studentID], nil
+}
+
+// RemoveStudent makes StudentExists report the student as unknown; every other ID exists
+func (m *MockAchievementRepository) RemoveStudent(studentID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.missingStudents == nil {
+		m.missingStudents = make(map[string]bool)
+	}
+	m.missingStudents[studentID] = true
+}
+
+func (m *MockAchievementRepository) GetUserNames(userIDs []string) (map[string]string, error) {
+	names := make(map[string]string)
+	for _, id := range userIDs {
+		names[id] = "Test Lecturer " + id
+	}
+	return names, nil
+}
+
 func (m *MockAchievementRepository) GetAchievementsByStudentIDs(ctx context.Context, studentIDs []string) ([]model.Achievement, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -330,13 +756,397 @@ func (m *MockAchievementRepository) GetAchievementsByStudentIDs(ctx context.Cont
 		if achievement.IsDeleted {
 			continue
 		}
-		for _, studentID := range studentIDs {
-			if achievement.StudentID == studentID {
-				results = append(results, *achievement)
+		if involvesStudent(achievement, studentIDs) {
+			results = append(results, *achievement)
+		}
+	}
+
+	return results, nil
+}
+
+// involvesStudent reports whether any of the students created the achievement or is on its team
+func involvesStudent(achievement *model.Achievement, studentIDs []string) bool {
+	for _, studentID := range studentIDs {
+		if achievement.StudentID == studentID {
+			return true
+		}
+		for _, member := range achievement.TeamMembers {
+			if member.StudentID == studentID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *MockAchievementRepository) GetStatusHistory(refID string) ([]model.AchievementStatusHistory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]model.AchievementStatusHistory{}, m.statusHistory[refID]...), nil
+}
+
+func (m *MockAchievementRepository) GetAchievementReferencesByStatuses(statuses []string) ([]model.AchievementReference, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var refs []model.AchievementReference
+	for _, ref := range m.achievementReferences {
+		for _, status := range statuses {
+			if ref.Status == status {
+				refs = append(refs, *ref)
 				break
 			}
 		}
 	}
 
-	return results, nil
-}
\ No newline at end of file
+	return refs, nil
+}
+
+func (m *MockAchievementRepository) GetApprovalStages(achievementType, competitionLevel string) ([]model.ApprovalStage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.approvalStagesErr != nil {
+		if m.approvalStagesOK == 0 {
+			return nil, m.approvalStagesErr
+		}
+		m.approvalStagesOK--
+	}
+
+	if chain, exists := m.approvalChains[achievementType+"|"+competitionLevel]; exists {
+		return chain.Stages, nil
+	}
+	if chain, exists := m.approvalChains[achievementType+"|"]; exists {
+		return chain.Stages, nil
+	}
+
+	return []model.ApprovalStage{}, nil
+}
+
+// FailApprovalStages makes GetApprovalStages return err once it has answered the next succeed calls
+func (m *MockAchievementRepository) FailApprovalStages(succeed int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.approvalStagesOK = succeed
+	m.approvalStagesErr = err
+}
+
+func (m *MockAchievementRepository) GetApprovalChains() ([]model.ApprovalChain, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	chains := []model.ApprovalChain{}
+	for _, chain := range m.approvalChains {
+		chains = append(chains, chain)
+	}
+
+	return chains, nil
+}
+
+func (m *MockAchievementRepository) SaveApprovalChain(chain model.ApprovalChain) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range chain.Stages {
+		chain.Stages[i].Order = i + 1
+	}
+	m.approvalChains[chain.AchievementType+"|"+chain.CompetitionLevel] = chain
+
+	return nil
+}
+
+func (m *MockAchievementRepository) CreateComment(comment *model.AchievementComment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.commentErr != nil {
+		return m.commentErr
+	}
+	m.storeComment(comment)
+	return nil
+}
+
+// FailComments makes every comment insert fail with err, including those saved with a transition
+func (m *MockAchievementRepository) FailComments(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.commentErr = err
+}
+
+func (m *MockAchievementRepository) storeComment(comment *model.AchievementComment) {
+	comment.ID = fmt.Sprintf("comment-%d", len(m.comments)+1)
+	comment.CreatedAt = time.Now()
+	stored := *comment
+	m.comments = append(m.comments, &stored)
+}
+
+func (m *MockAchievementRepository) GetComment(id string) (*model.AchievementComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.comments {
+		if c.ID == id {
+			comment := *c
+			return &comment, nil
+		}
+	}
+
+	return nil, errors.New("comment not found")
+}
+
+func (m *MockAchievementRepository) GetComments(refID string) ([]model.AchievementComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comments := []model.AchievementComment{}
+	for _, c := range m.comments {
+		if c.AchievementReferenceID == refID {
+			comments = append(comments, *c)
+		}
+	}
+
+	return comments, nil
+}
+
+func (m *MockAchievementRepository) ResolveComment(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.comments {
+		if c.ID == id || (c.ParentID != nil && *c.ParentID == id) {
+			c.Resolved = true
+		}
+	}
+
+	return nil
+}
+
+func (m *MockAchievementRepository) GetCommentCounts(refIDs []string) (map[string]model.CommentCount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[string]model.CommentCount)
+	for _, refID := range refIDs {
+		for _, c := range m.comments {
+			if c.AchievementReferenceID != refID {
+				continue
+			}
+			count := counts[refID]
+			count.Total++
+			if c.ParentID == nil && !c.Resolved {
+				count.Unresolved++
+			}
+			counts[refID] = count
+		}
+	}
+
+	return counts, nil
+}
+
+func (m *MockAchievementRepository) GetOverdueAchievementReferences(studentIDs []string, submittedBefore time.Time) ([]model.AchievementReference, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var refs []model.AchievementReference
+	for _, ref := range m.achievementReferences {
+		if ref.Status != "submitted" && !strings.HasSuffix(ref.Status, "_approved") {
+			continue
+		}
+		if ref.SubmittedAt == nil || !ref.SubmittedAt.Before(submittedBefore) {
+			continue
+		}
+		if len(studentIDs) > 0 && !containsString(studentIDs, ref.StudentID) {
+			continue
+		}
+		refs = append(refs, *ref)
+	}
+
+	return refs, nil
+}
+
+func (m *MockAchievementRepository) GetVerifierTurnaround(studentIDs []string) ([]model.VerifierTurnaround, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, ref := range m.achievementReferences {
+		if ref.Status != "verified" || ref.VerifiedBy == nil || ref.SubmittedAt == nil || ref.VerifiedAt == nil {
+			continue
+		}
+		if len(studentIDs) > 0 && !containsString(studentIDs, ref.StudentID) {
+			continue
+		}
+		totals[*ref.VerifiedBy] += ref.VerifiedAt.Sub(*ref.SubmittedAt).Hours()
+		counts[*ref.VerifiedBy]++
+	}
+
+	turnaround := []model.VerifierTurnaround{}
+	for verifier, count := range counts {
+		turnaround = append(turnaround, model.VerifierTurnaround{
+			VerifierID: verifier,
+			Verified:   count,
+			AvgHours:   totals[verifier] / float64(count),
+		})
+	}
+
+	return turnaround, nil
+}
+
+// SetSubmittedAt backdates a submission for SLA tests
+func (m *MockAchievementRepository) SetSubmittedAt(mongoID string, submittedAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ref := range m.achievementReferences {
+		if ref.MongoAchievementID == mongoID {
+			ref.SubmittedAt = &submittedAt
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockAchievementRepository) GetPointsRuleSet() (*model.PointsRuleSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.pointsRuleSets) == 0 {
+		return &model.PointsRuleSet{Rules: []model.PointsRule{}, Caps: []model.PointsCap{}}, nil
+	}
+	ruleSet := m.pointsRuleSets[len(m.pointsRuleSets)-1]
+	return &ruleSet, nil
+}
+
+func (m *MockAchievementRepository) SavePointsRuleSet(rules []model.PointsRule, caps []model.PointsCap, teamPolicy, createdBy string) (*model.PointsRuleSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	ruleSet := model.PointsRuleSet{
+		Version:   len(m.pointsRuleSets) + 1,
+		Caps:       append([]model.PointsCap{}, caps...),
+		TeamPolicy: teamPolicy,
+		CreatedBy:  createdBy,
+		CreatedAt: &now,
+	}
+	for i, rule := range rules {
+		rule.ID = fmt.Sprintf("rule-%d-%d", ruleSet.Version, i+1)
+		ruleSet.Rules = append(ruleSet.Rules, rule)
+	}
+	m.pointsRuleSets = append(m.pointsRuleSets, ruleSet)
+
+	return &ruleSet, nil
+}
+
+func (m *MockAchievementRepository) GetAchievementTypes() ([]model.AchievementTypeDefinition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	definitions := []model.AchievementTypeDefinition{}
+	for _, definition := range m.achievementTypes {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+
+	return definitions, nil
+}
+
+func (m *MockAchievementRepository) GetAchievementType(name string) (*model.AchievementTypeDefinition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	definition, exists := m.achievementTypes[name]
+	if !exists {
+		return nil, errors.New("achievement type not found")
+	}
+
+	return &definition, nil
+}
+
+func (m *MockAchievementRepository) SaveAchievementType(definition *model.AchievementTypeDefinition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.achievementTypes == nil {
+		m.achievementTypes = make(map[string]model.AchievementTypeDefinition)
+	}
+	now := time.Now()
+	definition.UpdatedAt = &now
+	if existing, exists := m.achievementTypes[definition.Name]; exists {
+		definition.CreatedAt = existing.CreatedAt
+	} else {
+		definition.CreatedAt = &now
+	}
+	m.achievementTypes[definition.Name] = *definition
+
+	return nil
+}
+
+func (m *MockAchievementRepository) GetAcademicPeriods() ([]model.AcademicPeriod, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	periods := []model.AcademicPeriod{}
+	for _, period := range m.academicPeriods {
+		periods = append(periods, period)
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].StartDate.Before(periods[j].StartDate)
+	})
+
+	return periods, nil
+}
+
+func (m *MockAchievementRepository) SaveAcademicPeriod(period *model.AcademicPeriod) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.academicPeriods == nil {
+		m.academicPeriods = make(map[string]model.AcademicPeriod)
+	}
+	now := time.Now()
+	period.UpdatedAt = &now
+	if existing, exists := m.academicPeriods[period.Code]; exists {
+		period.CreatedAt = existing.CreatedAt
+	} else {
+		period.CreatedAt = &now
+	}
+	m.academicPeriods[period.Code] = *period
+
+	return nil
+}
+
+func (m *MockAchievementRepository) DeleteAcademicPeriod(code string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.academicPeriods, code)
+	return nil
+}
+
+func (m *MockAchievementRepository) CreateTranscriptDocument(doc *model.TranscriptDocument, render func() error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	doc.ID = fmt.Sprintf("transcript-%d", len(m.transcriptDocuments)+1)
+	doc.IssuedAt = time.Now()
+	doc.Number = fmt.Sprintf("SKPI/%d/%06d", doc.IssuedAt.Year(), len(m.transcriptDocuments)+1)
+	if err := render(); err != nil {
+		return err
+	}
+	m.transcriptDocuments = append(m.transcriptDocuments, *doc)
+	return nil
+}
@@ -0,0 +1,148 @@
+package mocks
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"student-report/app/model"
+	"student-report/app/repository"
+	"sync"
+	"time"
+)
+
+var _ repository.ILecturerRepository = (*MockLecturerRepository)(nil)
+
+type MockLecturerRepository struct {
+	mu          sync.Mutex
+	lecturers   map[string]string // userID -> lecturerID
+	overrides   map[string]bool   // lecturerID -> override granted
+	delegations []model.LecturerDelegation
+	nextID      int
+}
+
+func NewMockLecturerRepository() *MockLecturerRepository {
+	return &MockLecturerRepository{
+		lecturers: make(map[string]string),
+		overrides: make(map[string]bool),
+		nextID:    1,
+	}
+}
+
+// AddLecturer registers the lecturer profile of a user
+func (m *MockLecturerRepository) AddLecturer(userID, lecturerID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lecturers[userID] = lecturerID
+}
+
+func (m *MockLecturerRepository) GetLecturersRepository() ([]model.Lecturers, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lecturers := []model.Lecturers{}
+	for userID, lecturerID := range m.lecturers {
+		lecturers = append(lecturers, model.Lecturers{ID: lecturerID, UserID: userID})
+	}
+	return lecturers, nil
+}
+
+func (m *MockLecturerRepository) GetLecturerIDByUserID(userID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lecturerID, exists := m.lecturers[userID]
+	if !exists {
+		return "", sql.ErrNoRows
+	}
+	return lecturerID, nil
+}
+
+func (m *MockLecturerRepository) HasVerificationOverride(lecturerID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.overrides[lecturerID], nil
+}
+
+func (m *MockLecturerRepository) GrantVerificationOverride(lecturerID, grantedBy string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.overrides[lecturerID] = true
+	return nil
+}
+
+func (m *MockLecturerRepository) RevokeVerificationOverride(lecturerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.overrides[lecturerID] {
+		return sql.ErrNoRows
+	}
+	delete(m.overrides, lecturerID)
+	return nil
+}
+
+func (m *MockLecturerRepository) SharesDepartmentWithAdvisor(lecturerID, studentID string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockLecturerRepository) GetDelegations(delegatorID string) ([]model.LecturerDelegation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delegations := []model.LecturerDelegation{}
+	for _, d := range m.delegations {
+		if d.DelegatorID == delegatorID {
+			delegations = append(delegations, d)
+		}
+	}
+	return delegations, nil
+}
+
+func (m *MockLecturerRepository) CreateDelegation(d *model.LecturerDelegation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if d.DelegatorID == d.DelegateID || d.EndDate.Before(d.StartDate) {
+		return errors.New("delegation violates check constraint")
+	}
+
+	d.ID = fmt.Sprintf("delegation-%d", m.nextID)
+	d.CreatedAt = time.Now()
+	m.nextID++
+	m.delegations = append(m.delegations, *d)
+	return nil
+}
+
+func (m *MockLecturerRepository) DeleteDelegation(delegatorID, delegationID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, d := range m.delegations {
+		if d.ID == delegationID && d.DelegatorID == delegatorID {
+			m.delegations = append(m.delegations[:i], m.delegations[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// GetActiveDelegatorIDs compares calendar days, like the DATE columns of lecturer_delegations
+func (m *MockLecturerRepository) GetActiveDelegatorIDs(delegateID string, at time.Time) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	day := at.Format("2006-01-02")
+	var delegatorIDs []string
+	for _, d := range m.delegations {
+		if d.DelegateID != delegateID || day < d.StartDate.Format("2006-01-02") || day > d.EndDate.Format("2006-01-02") {
+			continue
+		}
+		if !containsString(delegatorIDs, d.DelegatorID) {
+			delegatorIDs = append(delegatorIDs, d.DelegatorID)
+		}
+	}
+	return delegatorIDs, nil
+}
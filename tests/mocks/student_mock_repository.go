@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"database/sql"
+	"student-report/app/model"
+	"student-report/app/repository"
+	"sync"
+)
+
+var _ repository.IStudentRepository = (*MockStudentRepository)(nil)
+
+type MockStudentRepository struct {
+	mu       sync.Mutex
+	students []model.Students
+}
+
+func NewMockStudentRepository() *MockStudentRepository {
+	return &MockStudentRepository{}
+}
+
+// AddStudent registers a student profile advised by the given lecturer
+func (m *MockStudentRepository) AddStudent(studentID, userID, advisorID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.students = append(m.students, model.Students{ID: studentID, UserID: userID, AdvisorID: advisorID})
+}
+
+func (m *MockStudentRepository) GetStudentsRepository() ([]model.Students, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]model.Students{}, m.students...), nil
+}
+
+func (m *MockStudentRepository) GetStudentByUserID(userID string) (*model.Students, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, student := range m.students {
+		if student.UserID == userID {
+			found := student
+			return &found, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *MockStudentRepository) GetStudentByAdvisorID(advisorID string) ([]model.Students, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var advisees []model.Students
+	for _, student := range m.students {
+		if student.AdvisorID == advisorID {
+			advisees = append(advisees, student)
+		}
+	}
+	return advisees, nil
+}
@@ -0,0 +1,307 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"student-report/app/model"
+	"student-report/app/points"
+	"student-report/app/service"
+	"student-report/tests/mocks"
+	"testing"
+	"time"
+)
+
+// Test Points Engine Rule Matching
+func TestPointsEngine_Calculate(t *testing.T) {
+	engine := points.NewEngine(points.DefaultRuleSet())
+
+	tests := []struct {
+		name        string
+		achievement model.Achievement
+		expected    int
+	}{
+		{
+			name: "International competition winner",
+			achievement: model.Achievement{
+				AchievementType: "competition",
+				Details:         model.AchievementDetails{CompetitionLevel: "international", Rank: 1},
+			},
+			expected: 100,
+		},
+		{
+			name: "National competition participant",
+			achievement: model.Achievement{
+				AchievementType: "competition",
+				Details:         model.AchievementDetails{CompetitionLevel: "national"},
+			},
+			expected: 25,
+		},
+		{
+			name: "Journal publication",
+			achievement: model.Achievement{
+				AchievementType: "publication",
+				Details:         model.AchievementDetails{PublicationType: "journal"},
+			},
+			expected: 50,
+		},
+		{
+			name:        "Unknown type scores nothing",
+			achievement: model.Achievement{AchievementType: "hobby"},
+			expected:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := engine.Calculate(&tt.achievement)
+			if result.Points != tt.expected {
+				t.Errorf("expected %d points, got %d (rule %s)", tt.expected, result.Points, result.RuleID)
+			}
+		})
+	}
+}
+
+// Test Points Are Computed, Not Taken From the Request
+func TestAchievementService_PointsFromRules(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "competition",
+		Title:           "Local Seminar Quiz",
+		Points:          1000,
+		Details:         model.AchievementDetails{CompetitionName: "Seminar Quiz", CompetitionLevel: "local"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+	if created.Points != 5 || created.PointsVersion != 0 {
+		t.Errorf("expected 5 points from default rules, got %d (version %d)", created.Points, created.PointsVersion)
+	}
+
+	// Updates are rescored
+	updated, err := svc.UpdateAchievement(ctx, created.ID.Hex(), "student-1", model.UpdateAchievementRequest{
+		Title:   "Local Seminar Quiz",
+		Points:  1000,
+		Details: model.AchievementDetails{CompetitionName: "Seminar Quiz", CompetitionLevel: "local", Rank: 1},
+	})
+	if err != nil {
+		t.Fatalf("failed to update achievement: %v", err)
+	}
+	if updated.Points != 20 {
+		t.Errorf("expected 20 points after update, got %d", updated.Points)
+	}
+
+	if _, err := svc.SavePointsRuleSet([]model.PointsRule{{Points: 10}}, nil, "", "admin-1"); err == nil {
+		t.Errorf("expected error saving a rule without achievement type")
+	}
+
+	ruleSet, err := svc.SavePointsRuleSet([]model.PointsRule{
+		{AchievementType: "competition", CompetitionLevel: "local", MinRank: 1, MaxRank: 3, Points: 30},
+	}, nil, "", "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changed, err := svc.RecomputeAllPoints(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("expected 1 achievement rescored, got %d", changed)
+	}
+
+	result, err := svc.GetAchievementByID(ctx, created.ID.Hex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Points != 30 || result.PointsVersion != ruleSet.Version {
+		t.Errorf("expected 30 points at version %d, got %d at version %d", ruleSet.Version, result.Points, result.PointsVersion)
+	}
+}
+
+// Test Points Only Count Once Verified
+func TestAchievementService_VerifiedPointsOnly(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	create := func(studentID, title string) string {
+		created, err := svc.CreateAchievement(ctx, studentID, model.CreateAchievementRequest{
+			AchievementType: "organization",
+			Title:           title,
+			Details:         model.AchievementDetails{OrganizationName: title, Position: "member"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		return created.ID.Hex()
+	}
+
+	verified := create("student-1", "Student Council")
+	pending := create("student-1", "Debate Club")
+	rejected := create("student-1", "Chess Club")
+	create("student-1", "Draft Club")
+	create("student-2", "Photography Club")
+
+	for _, id := range []string{verified, pending, rejected} {
+		if _, err := svc.SubmitForVerification(ctx, id, "student-1"); err != nil {
+			t.Fatalf("failed to submit achievement: %v", err)
+		}
+	}
+	if _, err := svc.VerifyAchievement(ctx, verified, "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+		t.Fatalf("failed to verify achievement: %v", err)
+	}
+	note := "Missing certificate"
+	if _, err := svc.VerifyAchievement(ctx, rejected, "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "reject", Note: &note}); err != nil {
+		t.Fatalf("failed to reject achievement: %v", err)
+	}
+
+	report, err := svc.GetStudentReport(ctx, "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalPoints != 20 || report.VerifiedPoints != 20 || report.PendingPoints != 20 || report.RejectedPoints != 20 {
+		t.Errorf("expected 20 verified/pending/rejected points, got total=%d %+v", report.TotalPoints, report.PointsBreakdown)
+	}
+
+	stats, err := svc.GetStatistics(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalPoints != 20 || stats.PendingPoints != 20 || stats.RejectedPoints != 20 {
+		t.Errorf("expected drafts and unverified points excluded from total, got total=%d %+v", stats.TotalPoints, stats.PointsBreakdown)
+	}
+	if len(stats.TopStudents) != 1 || stats.TopStudents[0].StudentID != "student-1" || stats.TopStudents[0].TotalPoints != 20 {
+		t.Errorf("expected only student-1 on the leaderboard with 20 points, got %+v", stats.TopStudents)
+	}
+
+	// An empty scope (a lecturer without advisees) sees nothing rather than everyone
+	stats, err = svc.GetStatistics(ctx, []string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalAchievements != 0 || stats.TotalPoints != 0 || stats.PendingPoints != 0 || len(stats.TopStudents) != 0 {
+		t.Errorf("expected empty statistics for an empty scope, got %+v", stats)
+	}
+}
+
+// Test Academic Period Keys
+func TestPointsEngine_PeriodKey(t *testing.T) {
+	tests := []struct {
+		date     string
+		period   string
+		expected string
+	}{
+		{"2025-09-01", points.CapPeriodSemester, "2025-GANJIL"},
+		{"2026-01-15", points.CapPeriodSemester, "2025-GANJIL"},
+		{"2026-03-01", points.CapPeriodSemester, "2025-GENAP"},
+		{"2026-03-01", points.CapPeriodAcademicYear, "2025/2026"},
+	}
+
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := points.PeriodKey(date, tt.period); got != tt.expected {
+			t.Errorf("PeriodKey(%s, %s) = %s, expected %s", tt.date, tt.period, got, tt.expected)
+		}
+	}
+}
+
+// Test Period Keys Follow the Configured Academic Periods
+func TestPointsEngine_ResolvePeriodKey(t *testing.T) {
+	day := func(s string) time.Time {
+		date, _ := time.Parse("2006-01-02", s)
+		return date
+	}
+	periods := []model.AcademicPeriod{
+		{Code: "2025-GANJIL", AcademicYear: "2025/2026", StartDate: day("2025-09-01"), EndDate: day("2026-02-15")},
+		{Code: "2025-GENAP", AcademicYear: "2025/2026", StartDate: day("2026-02-16"), EndDate: day("2026-06-30")},
+		{Code: "2025-PENDEK", AcademicYear: "2025/2026", StartDate: day("2026-07-01"), EndDate: day("2026-08-31")},
+	}
+
+	tests := []struct {
+		date     string
+		period   string
+		expected string
+	}{
+		{"2026-02-15", points.CapPeriodSemester, "2025-GANJIL"},
+		{"2026-02-16", points.CapPeriodSemester, "2025-GENAP"},
+		{"2026-08-15", points.CapPeriodSemester, "2025-PENDEK"},
+		{"2026-08-15", points.CapPeriodAcademicYear, "2025/2026"},
+		{"2025-08-15", points.CapPeriodSemester, "2025-GANJIL"}, // not configured: default calendar
+	}
+
+	for _, tt := range tests {
+		if got := points.ResolvePeriodKey(day(tt.date), tt.period, periods); got != tt.expected {
+			t.Errorf("ResolvePeriodKey(%s, %s) = %s, expected %s", tt.date, tt.period, got, tt.expected)
+		}
+	}
+}
+
+// Test Per-Semester Point Caps in the Student Report
+func TestAchievementService_PointCaps(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	// Default rules: organization scores 20, capped at 40 per semester
+	dates := []string{"2025-09-01", "2025-10-01", "2025-11-01", "2026-03-01"}
+	for i, date := range dates {
+		created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "organization",
+			Title:           fmt.Sprintf("Committee %d", i+1),
+			Details:         model.AchievementDetails{OrganizationName: "BEM", Position: "committee", EventDate: date},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		if _, err := svc.SubmitForVerification(ctx, created.ID.Hex(), "student-1"); err != nil {
+			t.Fatalf("failed to submit achievement: %v", err)
+		}
+		if _, err := svc.VerifyAchievement(ctx, created.ID.Hex(), "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+			t.Fatalf("failed to verify achievement: %v", err)
+		}
+	}
+
+	report, err := svc.GetStudentReport(ctx, "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RawTotalPoints != 80 {
+		t.Errorf("expected 80 raw points, got %d", report.RawTotalPoints)
+	}
+	if report.TotalPoints != 60 {
+		t.Errorf("expected 60 capped points, got %d", report.TotalPoints)
+	}
+	if len(report.Clipped) != 1 || report.Clipped[0].Title != "Committee 3" || report.Clipped[0].Period != "2025-GANJIL" {
+		t.Fatalf("expected Committee 3 to be clipped in 2025-GANJIL, got %+v", report.Clipped)
+	}
+	if report.Clipped[0].CountedPoints != 0 {
+		t.Errorf("expected clipped achievement to count 0 points, got %d", report.Clipped[0].CountedPoints)
+	}
+
+	// The leaderboard and statistics count the same capped total as the report
+	stats, err := svc.GetStatistics(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats.TopStudents) != 1 || stats.TopStudents[0].TotalPoints != report.TotalPoints {
+		t.Errorf("expected student-1 on the leaderboard with the report's %d points, got %+v", report.TotalPoints, stats.TopStudents)
+	}
+	if stats.TotalPoints != report.TotalPoints {
+		t.Errorf("expected statistics to total the report's %d points, got %d", report.TotalPoints, stats.TotalPoints)
+	}
+
+	// An odd semester running into March pulls Committee 4 under the same cap
+	if _, err := svc.SaveAcademicPeriod("2025-GANJIL", model.SaveAcademicPeriodRequest{StartDate: "2025-08-01", EndDate: "2026-03-15"}); err != nil {
+		t.Fatalf("failed to save academic period: %v", err)
+	}
+	report, err = svc.GetStudentReport(ctx, "student-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalPoints != 40 || len(report.Clipped) != 2 {
+		t.Errorf("expected 40 capped points with 2 clipped, got %d with %+v", report.TotalPoints, report.Clipped)
+	}
+}
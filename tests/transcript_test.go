@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"student-report/app/model"
+	"student-report/app/service"
+	"student-report/app/transcript"
+	"student-report/tests/mocks"
+	"testing"
+	"time"
+)
+
+// Test Issuing an Achievement Transcript (SKPI) as a PDF
+func TestAchievementService_IssueTranscript(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAchievementRepository()
+	svc := service.NewAchievementService(mockRepo)
+
+	verify := func(title string) {
+		created, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+			AchievementType: "certification",
+			Title:           title,
+			Details:         model.AchievementDetails{CertificationName: title, IssuedBy: "Oracle (Academy)"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create achievement: %v", err)
+		}
+		if _, err := svc.SubmitForVerification(ctx, created.ID.Hex(), "student-1"); err != nil {
+			t.Fatalf("failed to submit achievement: %v", err)
+		}
+		if _, err := svc.VerifyAchievement(ctx, created.ID.Hex(), "lecturer-1", service.RoleLecturer, model.VerifyAchievementRequest{Action: "verify"}); err != nil {
+			t.Fatalf("failed to verify achievement: %v", err)
+		}
+	}
+	verify("Java SE Programmer")
+	if _, err := svc.CreateAchievement(ctx, "student-1", model.CreateAchievementRequest{
+		AchievementType: "certification",
+		Title:           "Draft Certification",
+		Details:         model.AchievementDetails{CertificationName: "Draft Certification", IssuedBy: "Oracle"},
+	}); err != nil {
+		t.Fatalf("failed to create achievement: %v", err)
+	}
+
+	issued, err := svc.IssueTranscript(ctx, "student-1", nil, "admin-1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	year := time.Now().Year()
+	if issued.Document.Number != fmt.Sprintf("SKPI/%d/000001", year) || issued.Document.VerifiedAchievements != 1 || issued.Document.IssuedBy != "admin-1" {
+		t.Errorf("unexpected document: %+v", issued.Document)
+	}
+	if issued.VerifierNames["lecturer-1"] != "Test Lecturer lecturer-1" {
+		t.Errorf("expected the verifier's name to be looked up, got %v", issued.VerifierNames)
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.RenderPDF(&buf, issued); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pdf := buf.String()
+	checkPDFStructure(t, pdf)
+	for _, expected := range []string{"Nomor: " + issued.Document.Number, "Sertifikasi \\(1 prestasi\\)", "Java SE Programmer", "Oracle \\(Academy\\)", "Test Lecturer lecturer-1", "Halaman 1 dari 1"} {
+		if !strings.Contains(pdf, expected) {
+			t.Errorf("expected the transcript to contain %q", expected)
+		}
+	}
+	if strings.Contains(pdf, "Draft Certification") {
+		t.Error("expected unverified achievements to be left out")
+	}
+
+	// Every issue gets its own number, and long transcripts run over several pages
+	for i := 1; i <= 30; i++ {
+		verify(fmt.Sprintf("Certification %02d", i))
+	}
+	issued, err = svc.IssueTranscript(ctx, "student-1", nil, "admin-1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issued.Document.Number != fmt.Sprintf("SKPI/%d/000002", year) || issued.Document.VerifiedAchievements != 31 {
+		t.Errorf("unexpected document: %+v", issued.Document)
+	}
+
+	// Students and lecturers are limited to their own scope, and a refusal uses up no number
+	if _, err := svc.IssueTranscript(ctx, "student-1", nil, "user-2", []string{"student-2"}, nil); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for another student's transcript, got %v", err)
+	}
+	if _, err := svc.IssueTranscript(ctx, "student-1", nil, "lecturer-9", []string{}, nil); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for a lecturer without advisees, got %v", err)
+	}
+	own, err := svc.IssueTranscript(ctx, "student-1", nil, "user-1", []string{"student-1"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if own.Document.Number != fmt.Sprintf("SKPI/%d/000003", year) {
+		t.Errorf("expected the next number after refused requests, got %s", own.Document.Number)
+	}
+
+	// A transcript that fails to render is not recorded as issued
+	if _, err := svc.IssueTranscript(ctx, "student-1", nil, "admin-1", nil, func(*model.Transcript) error {
+		return errors.New("font missing")
+	}); err == nil {
+		t.Error("expected the render failure to be returned")
+	}
+	rendered, err := svc.IssueTranscript(ctx, "student-1", nil, "admin-1", nil, func(issued *model.Transcript) error {
+		if issued.Document.Number == "" {
+			t.Error("expected the document number before rendering")
+		}
+		return nil
+	})
+	if err != nil || rendered.Document.Number != fmt.Sprintf("SKPI/%d/000004", year) {
+		t.Errorf("expected no document recorded for the failed render, got %+v (%v)", rendered, err)
+	}
+
+	mockRepo.RemoveStudent("student-9")
+	if _, err := svc.IssueTranscript(ctx, "student-9", nil, "admin-1", nil, nil); !errors.Is(err, service.ErrAchievementNotFound) {
+		t.Errorf("expected ErrAchievementNotFound for an unknown student, got %v", err)
+	}
+
+	buf.Reset()
+	if err := transcript.RenderPDF(&buf, issued); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pdf = buf.String()
+	checkPDFStructure(t, pdf)
+	pages := regexp.MustCompile(`Halaman 1 dari (\d+)`).FindStringSubmatch(pdf)
+	if pages == nil || pages[1] == "1" {
+		t.Fatalf("expected a transcript of several pages, got %v", pages)
+	}
+	if !strings.Contains(pdf, "Sertifikasi \\(31 prestasi\\) - lanjutan") || !strings.Contains(pdf, "Certification 30") {
+		t.Error("expected the table to continue on the next page")
+	}
+	if count, _ := strconv.Atoi(pages[1]); strings.Count(pdf, "/Type /Page ") != count {
+		t.Errorf("expected %d page objects, got %d", count, strings.Count(pdf, "/Type /Page "))
+	}
+}
+
+// checkPDFStructure follows the cross-reference table to every object
+func checkPDFStructure(t *testing.T, pdf string) {
+	t.Helper()
+	if !strings.HasPrefix(pdf, "%PDF-1.4\n") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatal("expected a PDF 1.4 header and an end-of-file marker")
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+	if startxref == nil {
+		t.Fatal("expected a startxref entry")
+	}
+	xref, _ := strconv.Atoi(startxref[1])
+	if !strings.HasPrefix(pdf[xref:], "xref\n") {
+		t.Fatalf("expected startxref to point at the xref table, found %q", pdf[xref:xref+10])
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllStringSubmatch(pdf[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if header := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(pdf[offset:], header) {
+			t.Errorf("expected object %d at offset %d", i+1, offset)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// SchemaError is a single JSON-schema violation at a dotted path
+type SchemaError struct {
+	Path    string
+	Message string
+}
+
+var supportedSchemaTypes = map[string]bool{
+	"object": true, "string": true, "number": true, "integer": true, "boolean": true, "array": true,
+}
+
+// CheckJSONSchema verifies that a schema only uses the subset ValidateJSONSchema understands:
+// type, properties, required, additionalProperties (bool), items, enum, minimum, maximum,
+// minLength, maxLength, pattern and format "date"
+func CheckJSONSchema(schema map[string]interface{}) error {
+	return checkSchema(schema, "")
+}
+
+func checkSchema(schema map[string]interface{}, path string) error {
+	where := path
+	if where == "" {
+		where = "schema"
+	}
+
+	if t, ok := schema["type"]; ok {
+		name, isString := t.(string)
+		if !isString || !supportedSchemaTypes[name] {
+			return fmt.Errorf("%s: unsupported type %v", where, t)
+		}
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%s: invalid pattern: %v", where, err)
+		}
+	}
+	if format, ok := schema["format"]; ok && format != "date" {
+		return fmt.Errorf("%s: unsupported format %v", where, format)
+	}
+	if required, ok := schema["required"]; ok {
+		if _, err := toStringSlice(required); err != nil {
+			return fmt.Errorf("%s: required must be a list of property names", where)
+		}
+	}
+	if properties, ok := schema["properties"]; ok {
+		props, isMap := properties.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("%s: properties must be an object", where)
+		}
+		for name, prop := range props {
+			propSchema, isMap := prop.(map[string]interface{})
+			if !isMap {
+				return fmt.Errorf("%s: property %s must be a schema object", where, name)
+			}
+			if err := checkSchema(propSchema, joinPath(path, name)); err != nil {
+				return err
+			}
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		itemSchema, isMap := items.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("%s: items must be a schema object", where)
+		}
+		if err := checkSchema(itemSchema, path+"[]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateJSONSchema validates a decoded JSON value against a schema accepted by CheckJSONSchema
+func ValidateJSONSchema(schema map[string]interface{}, value interface{}) []SchemaError {
+	var errs []SchemaError
+	validateValue(schema, value, "", &errs)
+	return errs
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, errs *[]SchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t, ok := schema["type"].(string); ok && !matchesType(t, value) {
+		fail("must be of type %s", t)
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %v", enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if min, ok := toFloat(schema["minLength"]); ok && float64(len(v)) < min {
+			fail("must be at least %v characters", min)
+		}
+		if max, ok := toFloat(schema["maxLength"]); ok && float64(len(v)) > max {
+			fail("must be at most %v characters", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				fail("must match pattern %s", pattern)
+			}
+		}
+		if schema["format"] == "date" {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				fail("must be a date in YYYY-MM-DD format")
+			}
+		}
+
+	case map[string]interface{}:
+		required, _ := toStringSlice(schema["required"])
+		for _, name := range required {
+			if _, present := v[name]; !present {
+				*errs = append(*errs, SchemaError{Path: joinPath(path, name), Message: "is required"})
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propSchema, known := properties[name].(map[string]interface{})
+			if !known {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					*errs = append(*errs, SchemaError{Path: joinPath(path, name), Message: "is not an allowed field"})
+				}
+				continue
+			}
+			validateValue(propSchema, v[name], joinPath(path, name), errs)
+		}
+
+	case []interface{}:
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateValue(itemSchema, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	}
+
+	if number, ok := toFloat(value); ok {
+		if min, ok := toFloat(schema["minimum"]); ok && number < min {
+			fail("must be at least %v", min)
+		}
+		if max, ok := toFloat(schema["maximum"]); ok && number > max {
+			fail("must be at most %v", max)
+		}
+	}
+}
+
+func matchesType(t string, value interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		n, ok := toFloat(value)
+		return ok && n == float64(int64(n))
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func toStringSlice(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return v, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", item)
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("expected list, got %T", value)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
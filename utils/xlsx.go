@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// XLSXWriter streams a single-sheet Office Open XML workbook. Cells are written as inline
+// strings, so rows go straight to the output and the whole sheet is never held in memory.
+type XLSXWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+// Style 0 is the default, style 1 is bold for the header row
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+
+// NewXLSXWriter writes the workbook parts and opens the sheet; call Close to finish the file
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	var name xmlText
+	name.write(sheetName)
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="` + string(name) + `" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	x := &XLSXWriter{zip: zip.NewWriter(w)}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		f, err := x.zip.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x.sheet = sheet
+	_, err = io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return x, err
+}
+
+// WriteHeader writes a row in bold
+func (x *XLSXWriter) WriteHeader(record []string) error {
+	return x.writeRow(record, 1)
+}
+
+// Write writes a row of text cells, like csv.Writer.Write
+func (x *XLSXWriter) Write(record []string) error {
+	return x.writeRow(record, 0)
+}
+
+func (x *XLSXWriter) writeRow(record []string, style int) error {
+	x.rows++
+	var row xmlText
+	row = append(row, fmt.Sprintf(`<row r="%d">`, x.rows)...)
+	for i, value := range record {
+		row = append(row, `<c r="`+XLSXColumnName(i)+strconv.Itoa(x.rows)+`" t="inlineStr"`...)
+		if style != 0 {
+			row = append(row, ` s="`+strconv.Itoa(style)+`"`...)
+		}
+		row = append(row, `><is><t xml:space="preserve">`...)
+		row.write(value)
+		row = append(row, `</t></is></c>`...)
+	}
+	row = append(row, `</row>`...)
+	_, err := x.sheet.Write(row)
+	return err
+}
+
+// Close ends the sheet and the zip archive; it does not close the underlying writer
+func (x *XLSXWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// XLSXColumnName turns a zero-based column index into its letters: 0 is A, 26 is AA
+func XLSXColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xmlText collects escaped XML character data
+type xmlText []byte
+
+func (t *xmlText) Write(p []byte) (int, error) {
+	*t = append(*t, p...)
+	return len(p), nil
+}
+
+// write appends the escaped value; characters XML cannot hold become U+FFFD
+func (t *xmlText) write(value string) {
+	xml.EscapeText(t, []byte(value))
+}